@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
 	"github.com/spf13/viper"
 	"github.com/strangelove-ventures/sl-exporter/cosmos"
 	"github.com/strangelove-ventures/sl-exporter/metrics"
@@ -14,6 +21,10 @@ type Config struct {
 	LogLevel  string
 	LogFormat string
 
+	// Proxy is the default proxy for outbound API calls, e.g. http://proxy:3128 or socks5://proxy:1080.
+	// Chains and endpoints may override it. Without a proxy, the HTTP_PROXY and HTTPS_PROXY environment variables apply.
+	Proxy string
+
 	Static struct {
 		Gauges []metrics.StaticGauge
 	}
@@ -29,3 +40,60 @@ func parseConfig(cfg *Config) error {
 	}
 	return viper.Unmarshal(cfg)
 }
+
+// endpointHeader returns the headers for requests to an endpoint, including authentication.
+// Errors do not include secret values.
+func endpointHeader(endpoint cosmos.Endpoint) (http.Header, error) {
+	if endpoint.BearerToken != "" && endpoint.BasicAuth.Username != "" {
+		return nil, errors.New("bearerToken and basicAuth are mutually exclusive")
+	}
+	header := make(http.Header)
+	for k, v := range endpoint.Headers {
+		value, err := resolveSecret(v)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", k, err)
+		}
+		header.Set(k, value)
+	}
+	if endpoint.BearerToken != "" {
+		token, err := resolveSecret(endpoint.BearerToken)
+		if err != nil {
+			return nil, fmt.Errorf("bearer token: %w", err)
+		}
+		header.Set("Authorization", "Bearer "+token)
+	}
+	if endpoint.BasicAuth.Username != "" {
+		username, err := resolveSecret(endpoint.BasicAuth.Username)
+		if err != nil {
+			return nil, fmt.Errorf("basic auth username: %w", err)
+		}
+		password, err := resolveSecret(endpoint.BasicAuth.Password)
+		if err != nil {
+			return nil, fmt.Errorf("basic auth password: %w", err)
+		}
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+	return header, nil
+}
+
+// resolveSecret reads an environment variable given env:NAME or a file given file:PATH.
+// Other values are returned as is.
+func resolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "env:"):
+		name := strings.TrimPrefix(value, "env:")
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, "file:"):
+		b, err := os.ReadFile(strings.TrimPrefix(value, "file:"))
+		if err != nil {
+			return "", err
+		}
+		// Files often end with a newline, which is never part of the secret.
+		return strings.TrimSpace(string(b)), nil
+	}
+	return value, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
@@ -75,7 +78,7 @@ func Execute() {
 
 	// Build all tasks
 	var tasks []metrics.Task
-	cosmosTasks := buildCosmosTasks(cosmosMets, internalMets, cfg)
+	cosmosTasks, streams, closers := buildCosmosTasks(cosmosMets, internalMets, cfg)
 	tasks = append(tasks, cosmosTasks...)
 
 	// Configure error group with signal handling.
@@ -116,8 +119,19 @@ func Execute() {
 		pool.Start(ctx)
 		return nil
 	})
+	for _, stream := range streams {
+		eg.Go(func() error {
+			stream.Run(ctx)
+			return nil
+		})
+	}
 
 	err = eg.Wait()
+	for _, c := range closers {
+		if cerr := c.Close(); cerr != nil {
+			slog.Warn("Failed to close client", "error", cerr)
+		}
+	}
 	switch {
 	case errors.Is(err, http.ErrServerClosed):
 		slog.Info("Server shutdown")
@@ -131,35 +145,227 @@ func logFatal(msg string, err error) {
 	os.Exit(1)
 }
 
-func buildCosmosTasks(cosmosMets *metrics.Cosmos, internalMets *metrics.Internal, cfg Config) []metrics.Task {
-	var tasks []metrics.Task
+// stateClient queries module state. Implemented by the REST, RPC and gRPC clients.
+type stateClient interface {
+	cosmos.AccountClient
+	cosmos.ValidatorClient
+	cosmos.StakingClient
+	cosmos.ValParamsClient
+}
+
+// buildCosmosTasks returns the tasks and block streams of all chains, and the clients to close on shutdown.
+func buildCosmosTasks(cosmosMets *metrics.Cosmos, internalMets *metrics.Internal, cfg Config) ([]metrics.Task, []*cosmos.BlockStream, []io.Closer) {
+	var (
+		tasks   []metrics.Task
+		streams []*cosmos.BlockStream
+		closers []io.Closer
+	)
 
+	clients := newHTTPClients(cfg.Proxy)
 	for _, chain := range cfg.Cosmos {
-		var urls []url.URL
-		for _, rest := range chain.Rest {
-			u, err := url.Parse(rest.URL)
+		var (
+			restClient *cosmos.RestClient
+			rpcClient  *cosmos.RPCClient
+			grpcClient *cosmos.GRPCClient
+			stream     *cosmos.BlockStream
+		)
+		if len(chain.Rest) > 0 {
+			fallback := newFallbackClient(internalMets, clients, chain, chain.Rest, metrics.WithCache(restCacheTTLs(chain)))
+			restClient = cosmos.NewRestClient(fallback)
+			if len(chain.Rest) > 1 {
+				tasks = append(tasks, cosmos.NewEndpointHeightTask(cosmosMets, fallback, chain))
+			}
+		}
+		if len(chain.RPC) > 0 {
+			rpcURLs := parseURLs(chain.RPC)
+			rpcClient = cosmos.NewRPCClient(newFallbackClient(internalMets, clients, chain, chain.RPC))
+			if chain.Stream {
+				stream = cosmos.NewBlockStream(internalMets, rpcURLs, chain)
+				for i, endpoint := range chain.RPC {
+					header, err := endpointHeader(endpoint)
+					if err != nil {
+						logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, rpcURLs[i].Hostname(), err))
+					}
+					stream.SetHeader(rpcURLs[i], header)
+					profile := clients.profile(chain, endpoint)
+					tlsConfig, err := buildTLSConfig(profile.TLS)
+					if err != nil {
+						logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, rpcURLs[i].Hostname(), err))
+					}
+					stream.SetTLSConfig(rpcURLs[i], tlsConfig)
+					if profile.Proxy != "" {
+						proxy, err := parseProxy(profile.Proxy)
+						if err != nil {
+							logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, rpcURLs[i].Hostname(), err))
+						}
+						stream.SetProxy(rpcURLs[i], proxy)
+					}
+				}
+				streams = append(streams, stream)
+			}
+		}
+		if len(chain.GRPC) > 0 {
+			fallback, err := newFallbackGRPCClient(internalMets, clients, chain)
 			if err != nil {
-				logFatal("Failed to parse cosmos rest url", err)
+				logFatal("Failed to create cosmos grpc client", err)
 			}
-			urls = append(urls, *u)
+			closers = append(closers, fallback)
+			grpcClient = cosmos.NewGRPCClient(fallback)
+		}
+
+		// The RPC is cheapest for block data. gRPC is preferred for module state because responses are typed.
+		var blockSrc cosmos.BlockClient
+		switch {
+		case rpcClient != nil:
+			blockSrc = rpcClient
+		case grpcClient != nil:
+			blockSrc = grpcClient
+		case restClient != nil:
+			blockSrc = restClient
+		default:
+			logFatal("Invalid cosmos chain config", fmt.Errorf("chain %s: at least one rest, rpc or grpc url is required", chain.ChainID))
+		}
+		var state stateClient
+		switch {
+		case grpcClient != nil:
+			state = grpcClient
+		case restClient != nil:
+			state = restClient
+		default:
+			state = rpcClient
+		}
+		if chain.Stream && stream == nil {
+			logFatal("Invalid cosmos chain config", fmt.Errorf("chain %s: stream requires at least one rpc url", chain.ChainID))
 		}
 
-		restClient := cosmos.NewRestClient(metrics.NewFallbackClient(httpClient, internalMets, urls))
-		tasks = append(tasks, cosmos.NewBlockHeightTask(cosmosMets, restClient, chain))
-		valTasks := cosmos.BuildValidatorTasks(cosmosMets, restClient, chain)
-		tasks = append(tasks, toTasks(valTasks)...)
-		if len(valTasks) > 0 {
-			tasks = append(tasks, cosmos.NewValParamsTask(cosmosMets, restClient, chain))
+		blocks := cosmos.NewBlockCache(internalMets, blockSrc, chain)
+		heightTask := cosmos.NewBlockHeightTask(cosmosMets, blocks, chain)
+		tasks = append(tasks, heightTask)
+		if len(chain.Validators) > 0 {
+			paramsTask := cosmos.NewValParamsTask(cosmosMets, state, chain)
+			tasks = append(tasks, paramsTask)
+			valTasks := cosmos.BuildValidatorTasks(cosmosMets, state, blocks, paramsTask.Params(), stream, chain)
+			tasks = append(tasks, toTasks(valTasks)...)
+			tasks = append(tasks, cosmos.NewStakingTask(cosmosMets, state, chain))
 		}
 
+		// Denom metadata requires REST. A nil *RestClient must not be passed as a non-nil interface.
+		var denoms cosmos.DenomClient
+		if restClient != nil {
+			denoms = restClient
+		}
 		// For loop works around tasks being an array of Task interface
-		accountTasks := cosmos.NewAccountTasks(cosmosMets, restClient, chain)
+		accountTasks := cosmos.NewAccountTasks(cosmosMets, state, denoms, chain)
 		for i := range accountTasks {
 			tasks = append(tasks, accountTasks[i])
 		}
+		balancesTasks, err := cosmos.NewAccountBalancesTasks(cosmosMets, state, denoms, chain)
+		if err != nil {
+			logFatal("Invalid cosmos account config", fmt.Errorf("chain %s: %w", chain.ChainID, err))
+		}
+		tasks = append(tasks, toTasks(balancesTasks)...)
+
+		if restClient == nil {
+			slog.Warn("No rest urls configured, skipping gov, upgrade and account staking metrics", "chain", chain.ChainID)
+			continue
+		}
+		tasks = append(tasks, toTasks(cosmos.NewAccountStakingTasks(cosmosMets, restClient, chain))...)
+		tasks = append(tasks, cosmos.NewGovTask(cosmosMets, restClient, chain))
+		tasks = append(tasks, cosmos.NewUpgradeTask(cosmosMets, restClient, blocks, heightTask, chain))
+	}
+
+	return tasks, streams, closers
+}
+
+// newFallbackClient returns a client for the endpoints with the chain's retry policy and each endpoint's
+// rate limit, headers and transport profile in addition to opts.
+func newFallbackClient(internalMets *metrics.Internal, clients *httpClients, chain cosmos.Chain, endpoints []cosmos.Endpoint, opts ...metrics.FallbackOption) *metrics.FallbackClient {
+	urls := parseURLs(endpoints)
+	opts = append(opts, metrics.WithChainID(chain.ChainID), metrics.WithRetry(retryPolicy(chain)))
+	for i, endpoint := range endpoints {
+		client, err := clients.get(clients.profile(chain, endpoint))
+		if err != nil {
+			logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, urls[i].Hostname(), err))
+		}
+		opts = append(opts, metrics.WithHTTPClient(urls[i], client))
+		if endpoint.RequestsPerSecond > 0 {
+			opts = append(opts, metrics.WithRateLimit(urls[i], endpoint.RequestsPerSecond, endpoint.Burst))
+		}
+		header, err := endpointHeader(endpoint)
+		if err != nil {
+			logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, urls[i].Hostname(), err))
+		}
+		opts = append(opts, metrics.WithHeader(urls[i], header))
+	}
+	return metrics.NewFallbackClient(httpClient, internalMets, urls, opts...)
+}
+
+// newFallbackGRPCClient returns a client for the chain's gRPC endpoints with the chain's retry policy and each
+// endpoint's rate limit, headers, TLS config and proxy.
+func newFallbackGRPCClient(internalMets *metrics.Internal, clients *httpClients, chain cosmos.Chain) (*metrics.FallbackGRPCClient, error) {
+	urls := parseURLs(chain.GRPC)
+	opts := []metrics.GRPCOption{metrics.WithGRPCChainID(chain.ChainID), metrics.WithGRPCRetry(retryPolicy(chain))}
+	for i, endpoint := range chain.GRPC {
+		profile := clients.profile(chain, endpoint)
+		if profile.Proxy != "" {
+			proxy, err := parseProxy(profile.Proxy)
+			if err != nil {
+				logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, urls[i].Hostname(), err))
+			}
+			opts = append(opts, metrics.WithGRPCDialer(urls[i], metrics.ProxyDialer(proxy)))
+		}
+		tlsConfig, err := buildTLSConfig(profile.TLS)
+		if err != nil {
+			logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, urls[i].Hostname(), err))
+		}
+		if tlsConfig != nil {
+			opts = append(opts, metrics.WithGRPCTLS(urls[i], tlsConfig))
+		}
+		if endpoint.RequestsPerSecond > 0 {
+			opts = append(opts, metrics.WithGRPCRateLimit(urls[i], endpoint.RequestsPerSecond, endpoint.Burst))
+		}
+		header, err := endpointHeader(endpoint)
+		if err != nil {
+			logFatal("Invalid cosmos endpoint config", fmt.Errorf("chain %s, host %s: %w", chain.ChainID, urls[i].Hostname(), err))
+		}
+		opts = append(opts, metrics.WithGRPCHeader(urls[i], header))
+	}
+	return metrics.NewFallbackGRPCClient(internalMets, urls, opts...)
+}
+
+// retryPolicy returns the chain's retry policy. Fields are mapped explicitly, so the config and metrics
+// types can change independently.
+func retryPolicy(chain cosmos.Chain) metrics.RetryPolicy {
+	return metrics.RetryPolicy{
+		MaxAttempts: chain.Retry.MaxAttempts,
+		Backoff:     chain.Retry.Backoff,
+		MaxBackoff:  chain.Retry.MaxBackoff,
 	}
+}
 
-	return tasks
+// restCacheTTLs returns the default REST cache TTLs with the chain's overrides. A TTL of 0 disables caching.
+func restCacheTTLs(chain cosmos.Chain) metrics.CacheTTLs {
+	ttls := maps.Clone(cosmos.RestCacheTTLs)
+	maps.Copy(ttls, chain.CacheTTLs)
+	maps.DeleteFunc(ttls, func(_ string, ttl time.Duration) bool { return ttl <= 0 })
+	return ttls
+}
+
+func parseURLs(endpoints []cosmos.Endpoint) []url.URL {
+	var urls []url.URL
+	for _, endpoint := range endpoints {
+		u, err := url.Parse(endpoint.URL)
+		if err != nil {
+			// The error includes the url, which may contain credentials.
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			logFatal("Failed to parse cosmos url", err)
+		}
+		urls = append(urls, *u)
+	}
+	return urls
 }
 
 func toTasks[T metrics.Task](tasks []T) []metrics.Task {
@@ -3,15 +3,19 @@ package cosmos
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 )
 
 type AccountMetrics interface {
-	SetAccountBalance(chain, alias, address, denom string, balance float64)
+	SetAccountBalance(chain, alias string, balance AccountBalance)
+	// SetAccountBalances replaces all balances of an account.
+	SetAccountBalances(chain, alias, address string, balances []AccountBalance)
 }
 
 type AccountClient interface {
 	AccountBalance(ctx context.Context, address, denom string) (AccountBalance, error)
+	AccountBalances(ctx context.Context, address string) ([]AccountBalance, error)
 }
 
 // AccountTask queries the Cosmos REST (aka LCD) API for account data and records metrics.
@@ -20,6 +24,7 @@ type AccountTask struct {
 	alias    string
 	chainID  string
 	client   AccountClient
+	denoms   *denomResolver
 	denom    string
 	interval time.Duration
 	metrics  AccountMetrics
@@ -28,15 +33,24 @@ type AccountTask struct {
 func (task AccountTask) Group() string { return task.chainID }
 func (task AccountTask) ID() string    { return fmt.Sprintf("%s-%s", task.address, task.denom) }
 
-func NewAccountTasks(metrics AccountMetrics, client AccountClient, chain Chain) []AccountTask {
-	var tasks []AccountTask
+// NewAccountTasks returns a task per denom of accounts with specific denoms. See NewAccountBalancesTasks.
+// If denoms is nil, display denoms are not resolved.
+func NewAccountTasks(metrics AccountMetrics, client AccountClient, denoms DenomClient, chain Chain) []AccountTask {
+	var (
+		tasks    []AccountTask
+		resolver = newDenomResolver(chain.ChainID, denoms)
+	)
 	for _, account := range chain.Accounts {
+		if account.AllDenoms() {
+			continue
+		}
 		for _, denom := range account.Denoms {
 			tasks = append(tasks, AccountTask{
 				address:  account.Address,
 				alias:    account.Alias,
 				chainID:  chain.ChainID,
 				client:   client,
+				denoms:   resolver,
 				denom:    denom,
 				interval: intervalOrDefault(chain.Interval),
 				metrics:  metrics,
@@ -53,12 +67,101 @@ func (task AccountTask) Interval() time.Duration {
 
 // Run queries the Endpoint server for data and records various metrics.
 func (task AccountTask) Run(ctx context.Context) error {
-	_, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	bal, err := task.client.AccountBalance(cctx, task.address, task.denom)
+	if err != nil {
+		return err
+	}
+	balances := []AccountBalance{bal}
+	task.denoms.resolve(ctx, balances)
+	task.metrics.SetAccountBalance(task.chainID, task.alias, balances[0])
+	return nil
+}
+
+// AccountBalancesTask records all balances of an account.
+type AccountBalancesTask struct {
+	address  string
+	alias    string
+	chainID  string
+	client   AccountClient
+	denoms   *denomResolver
+	include  *regexp.Regexp
+	exclude  *regexp.Regexp
+	interval time.Duration
+	metrics  AccountMetrics
+}
+
+func (task AccountBalancesTask) Group() string { return task.chainID }
+func (task AccountBalancesTask) ID() string    { return fmt.Sprintf("%s-all-balances", task.address) }
+
+// NewAccountBalancesTasks returns a task per account that queries all balances instead of specific denoms.
+// Returns an error if an account's include or exclude pattern is invalid.
+func NewAccountBalancesTasks(metrics AccountMetrics, client AccountClient, denoms DenomClient, chain Chain) ([]AccountBalancesTask, error) {
+	var (
+		tasks    []AccountBalancesTask
+		resolver = newDenomResolver(chain.ChainID, denoms)
+	)
+	for _, account := range chain.Accounts {
+		if !account.AllDenoms() {
+			continue
+		}
+		task := AccountBalancesTask{
+			address:  account.Address,
+			alias:    account.Alias,
+			chainID:  chain.ChainID,
+			client:   client,
+			denoms:   resolver,
+			interval: intervalOrDefault(chain.Interval),
+			metrics:  metrics,
+		}
+		var err error
+		if task.include, err = compileDenomPattern(account.IncludeDenoms); err != nil {
+			return nil, fmt.Errorf("account %s: include denoms: %w", account.Address, err)
+		}
+		if task.exclude, err = compileDenomPattern(account.ExcludeDenoms); err != nil {
+			return nil, fmt.Errorf("account %s: exclude denoms: %w", account.Address, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func compileDenomPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+// Interval is how often to poll the Endpoint server for data.
+func (task AccountBalancesTask) Interval() time.Duration {
+	return task.interval
+}
+
+// Run queries all balances of the account and records the non-zero balances of matching denoms.
+func (task AccountBalancesTask) Run(ctx context.Context) error {
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
 	defer cancel()
-	bal, err := task.client.AccountBalance(ctx, task.address, task.denom)
+	balances, err := task.client.AccountBalances(cctx, task.address)
 	if err != nil {
 		return err
 	}
-	task.metrics.SetAccountBalance(task.chainID, task.alias, bal.Account, bal.Denom, bal.Amount)
+	filtered := make([]AccountBalance, 0, len(balances))
+	for _, bal := range balances {
+		if bal.Amount == 0 || !task.matches(bal.Denom) {
+			continue
+		}
+		filtered = append(filtered, bal)
+	}
+	task.denoms.resolve(ctx, filtered)
+	task.metrics.SetAccountBalances(task.chainID, task.alias, task.address, filtered)
 	return nil
 }
+
+func (task AccountBalancesTask) matches(denom string) bool {
+	if task.include != nil && !task.include.MatchString(denom) {
+		return false
+	}
+	return task.exclude == nil || !task.exclude.MatchString(denom)
+}
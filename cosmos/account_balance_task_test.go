@@ -2,20 +2,30 @@ package cosmos
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
 type mockAccountClient struct {
-	GotAddress  string
-	GotDenom    string
-	StubBalance AccountBalance
+	GotAddress   string
+	GotDenom     string
+	StubBalance  AccountBalance
+	StubBalances []AccountBalance
+}
+
+func (m *mockAccountClient) AccountBalances(ctx context.Context, address string) ([]AccountBalance, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		panic("no deadline")
+	}
+	m.GotAddress = address
+	return m.StubBalances, nil
 }
 
 func (m *mockAccountClient) AccountBalance(ctx context.Context, address, denom string) (AccountBalance, error) {
-	if ctx == nil {
-		panic("nil context")
+	if _, ok := ctx.Deadline(); !ok {
+		panic("no deadline")
 	}
 	m.GotAddress = address
 	m.GotDenom = denom
@@ -28,14 +38,24 @@ type mockAccountMetrics struct {
 	GotAddress string
 	GotDenom   string
 	GotBalance float64
+
+	GotBalances []AccountBalance
 }
 
-func (m *mockAccountMetrics) SetAccountBalance(chain, alias, address, denom string, balance float64) {
+func (m *mockAccountMetrics) SetAccountBalances(chain, alias, address string, balances []AccountBalance) {
 	m.GotChain = chain
 	m.GotAlias = alias
 	m.GotAddress = address
-	m.GotDenom = denom
-	m.GotBalance = balance
+	m.GotBalances = balances
+}
+
+func (m *mockAccountMetrics) SetAccountBalance(chain, alias string, balance AccountBalance) {
+	m.GotChain = chain
+	m.GotAlias = alias
+	m.GotAddress = balance.Account
+	m.GotDenom = balance.Denom
+	m.GotBalance = balance.Amount
+	m.GotBalances = []AccountBalance{balance}
 }
 
 func TestAccountTask_Run(t *testing.T) {
@@ -71,7 +91,7 @@ func TestAccountTask_Run(t *testing.T) {
 			}
 			var metrics mockAccountMetrics
 
-			tasks := NewAccountTasks(&metrics, &client, chain)
+			tasks := NewAccountTasks(&metrics, &client, nil, chain)
 
 			require.Equal(t, 3, len(tasks))
 
@@ -91,3 +111,98 @@ func TestAccountTask_Run(t *testing.T) {
 		})
 	})
 }
+
+func TestAccountBalancesTask_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+
+		chain := Chain{
+			ChainID: "osmosis-1",
+			Accounts: []Account{
+				{Address: "osmo1234", Alias: "osmosis"},
+				{Address: "osmo456", Alias: "osmosis2", Denoms: []string{"*"}, ExcludeDenoms: "^ibc/"},
+				{Address: "osmo789", Alias: "osmosis3", Denoms: []string{"uosmo"}},
+			},
+		}
+		var client mockAccountClient
+		client.StubBalances = []AccountBalance{
+			{Account: "osmo456", Denom: "ibc/ABC", Amount: 1},
+			{Account: "osmo456", Denom: "uion", Amount: 0},
+			{Account: "osmo456", Denom: "uosmo", Amount: 123},
+		}
+		var metrics mockAccountMetrics
+
+		require.Len(t, NewAccountTasks(&metrics, &client, nil, chain), 1)
+
+		tasks, err := NewAccountBalancesTasks(&metrics, &client, nil, chain)
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		require.Equal(t, "osmosis-1", tasks[1].Group())
+		require.Equal(t, "osmo456-all-balances", tasks[1].ID())
+
+		err = tasks[1].Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, "osmo456", client.GotAddress)
+		require.Equal(t, "osmosis-1", metrics.GotChain)
+		require.Equal(t, "osmosis2", metrics.GotAlias)
+		require.Equal(t, "osmo456", metrics.GotAddress)
+		require.Equal(t, []AccountBalance{
+			{Account: "osmo456", Denom: "uosmo", Amount: 123, Metadata: DenomMetadata{Base: "uosmo"}},
+		}, metrics.GotBalances)
+	})
+
+	t.Run("include and exclude", func(t *testing.T) {
+		t.Parallel()
+
+		chain := Chain{
+			Accounts: []Account{{Address: "osmo1234", IncludeDenoms: "^(u|ibc/)", ExcludeDenoms: "^ibc/DUST"}},
+		}
+		var client mockAccountClient
+		client.StubBalances = []AccountBalance{
+			{Denom: "factory/osmo1/token", Amount: 1},
+			{Denom: "ibc/ABC", Amount: 2},
+			{Denom: "ibc/DUST", Amount: 3},
+			{Denom: "uosmo", Amount: 4},
+		}
+		var metrics mockAccountMetrics
+
+		tasks, err := NewAccountBalancesTasks(&metrics, &client, nil, chain)
+		require.NoError(t, err)
+		require.NoError(t, tasks[0].Run(ctx))
+
+		require.Equal(t, []string{"ibc/ABC", "uosmo"}, []string{metrics.GotBalances[0].Denom, metrics.GotBalances[1].Denom})
+	})
+
+	t.Run("denom resolution fails", func(t *testing.T) {
+		t.Parallel()
+
+		chain := Chain{ChainID: "osmosis-1", Accounts: []Account{{Address: "osmo1234"}}}
+		var client mockAccountClient
+		client.StubBalances = []AccountBalance{{Denom: "ibc/ABC", Amount: 2}, {Denom: "uosmo", Amount: 4}}
+		denoms := &mockDenomClient{MetadataErr: errors.New("boom"), TraceErr: errors.New("boom")}
+		var metrics mockAccountMetrics
+
+		tasks, err := NewAccountBalancesTasks(&metrics, &client, denoms, chain)
+		require.NoError(t, err)
+		require.NoError(t, tasks[0].Run(ctx))
+
+		require.Equal(t, []AccountBalance{
+			{Denom: "ibc/ABC", Amount: 2, Metadata: DenomMetadata{Base: "ibc/ABC"}},
+			{Denom: "uosmo", Amount: 4, Metadata: DenomMetadata{Base: "uosmo"}},
+		}, metrics.GotBalances)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		t.Parallel()
+
+		chain := Chain{Accounts: []Account{{Address: "osmo1234", ExcludeDenoms: "("}}}
+		_, err := NewAccountBalancesTasks(&mockAccountMetrics{}, &mockAccountClient{}, nil, chain)
+
+		require.ErrorContains(t, err, "account osmo1234: exclude denoms")
+	})
+}
@@ -0,0 +1,89 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type AccountStakingMetrics interface {
+	// SetAccountStaking replaces the staking metrics of an account.
+	SetAccountStaking(chain, alias, address string, staking AccountStaking)
+}
+
+type AccountStakingClient interface {
+	BondDenom(ctx context.Context) (string, error)
+	Delegations(ctx context.Context, delegator string) ([]Delegation, error)
+	UnbondingDelegations(ctx context.Context, delegator string) ([]UnbondingEntry, error)
+	Redelegations(ctx context.Context, delegator string) ([]RedelegationEntry, error)
+	DelegationRewards(ctx context.Context, delegator string) ([]DelegationReward, error)
+}
+
+// AccountStakingTask records the delegations, unbondings, redelegations and claimable rewards of an account.
+type AccountStakingTask struct {
+	address string
+	alias   string
+	chainID string
+	client  AccountStakingClient
+	metrics AccountStakingMetrics
+}
+
+func NewAccountStakingTasks(metrics AccountStakingMetrics, client AccountStakingClient, chain Chain) []AccountStakingTask {
+	var tasks []AccountStakingTask
+	for _, account := range chain.Accounts {
+		tasks = append(tasks, AccountStakingTask{
+			address: account.Address,
+			alias:   account.Alias,
+			chainID: chain.ChainID,
+			client:  client,
+			metrics: metrics,
+		})
+	}
+	return tasks
+}
+
+func (task AccountStakingTask) Group() string { return task.chainID }
+func (task AccountStakingTask) ID() string    { return fmt.Sprintf("%s-staking", task.address) }
+
+// Interval is hardcoded to a longer duration because staking positions change infrequently and each run
+// makes several requests.
+func (task AccountStakingTask) Interval() time.Duration { return time.Minute }
+
+// Run records the staking metrics of the account. Metrics are only updated if all queries succeed.
+func (task AccountStakingTask) Run(ctx context.Context) error {
+	var (
+		staking AccountStaking
+		err     error
+	)
+	if staking.BondDenom, err = queryWithTimeout(ctx, task.client.BondDenom); err != nil {
+		return fmt.Errorf("bond denom: %w", err)
+	}
+	if staking.Delegations, err = queryAccountWithTimeout(ctx, task.address, task.client.Delegations); err != nil {
+		return fmt.Errorf("delegations: %w", err)
+	}
+	if staking.Unbondings, err = queryAccountWithTimeout(ctx, task.address, task.client.UnbondingDelegations); err != nil {
+		return fmt.Errorf("unbonding delegations: %w", err)
+	}
+	if staking.Redelegations, err = queryAccountWithTimeout(ctx, task.address, task.client.Redelegations); err != nil {
+		return fmt.Errorf("redelegations: %w", err)
+	}
+	if staking.Rewards, err = queryAccountWithTimeout(ctx, task.address, task.client.DelegationRewards); err != nil {
+		return fmt.Errorf("rewards: %w", err)
+	}
+	task.metrics.SetAccountStaking(task.chainID, task.alias, task.address, staking)
+	return nil
+}
+
+// queryWithTimeout calls fn with a request timeout.
+func queryWithTimeout[T any](ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	return fn(cctx)
+}
+
+// queryAccountWithTimeout calls fn for the address with a request timeout.
+func queryAccountWithTimeout[T any](ctx context.Context, address string, fn func(context.Context, string) (T, error)) (T, error) {
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	return fn(cctx, address)
+}
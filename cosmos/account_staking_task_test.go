@@ -0,0 +1,128 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockAccountStakingClient struct {
+	GotDelegators []string
+
+	StubDelegations []Delegation
+	StubUnbondings  []UnbondingEntry
+	StubRewards     []DelegationReward
+	RedelegationErr error
+}
+
+func (m *mockAccountStakingClient) requireDeadline(ctx context.Context) {
+	if _, ok := ctx.Deadline(); !ok {
+		panic("no deadline")
+	}
+}
+
+func (m *mockAccountStakingClient) BondDenom(ctx context.Context) (string, error) {
+	m.requireDeadline(ctx)
+	return "uatom", nil
+}
+
+func (m *mockAccountStakingClient) Delegations(ctx context.Context, delegator string) ([]Delegation, error) {
+	m.requireDeadline(ctx)
+	m.GotDelegators = append(m.GotDelegators, delegator)
+	return m.StubDelegations, nil
+}
+
+func (m *mockAccountStakingClient) UnbondingDelegations(ctx context.Context, delegator string) ([]UnbondingEntry, error) {
+	m.requireDeadline(ctx)
+	m.GotDelegators = append(m.GotDelegators, delegator)
+	return m.StubUnbondings, nil
+}
+
+func (m *mockAccountStakingClient) Redelegations(ctx context.Context, delegator string) ([]RedelegationEntry, error) {
+	m.requireDeadline(ctx)
+	m.GotDelegators = append(m.GotDelegators, delegator)
+	return nil, m.RedelegationErr
+}
+
+func (m *mockAccountStakingClient) DelegationRewards(ctx context.Context, delegator string) ([]DelegationReward, error) {
+	m.requireDeadline(ctx)
+	m.GotDelegators = append(m.GotDelegators, delegator)
+	return m.StubRewards, nil
+}
+
+type mockAccountStakingMetrics struct {
+	Calls      int
+	GotChain   string
+	GotAlias   string
+	GotAddress string
+	GotStaking AccountStaking
+}
+
+func (m *mockAccountStakingMetrics) SetAccountStaking(chain, alias, address string, staking AccountStaking) {
+	m.Calls++
+	m.GotChain = chain
+	m.GotAlias = alias
+	m.GotAddress = address
+	m.GotStaking = staking
+}
+
+func TestAccountStakingTask_Run(t *testing.T) {
+	t.Parallel()
+
+	chain := Chain{
+		ChainID: "cosmoshub-4",
+		Accounts: []Account{
+			{Address: "cosmos123", Alias: "treasury"},
+			{Address: "cosmos456", Alias: "self-delegation", Denoms: []string{"uatom"}},
+		},
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+
+		completion := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		client := mockAccountStakingClient{
+			StubDelegations: []Delegation{{Validator: "cosmosvaloper1", Denom: "uatom", Amount: 100}},
+			StubUnbondings:  []UnbondingEntry{{Validator: "cosmosvaloper1", CreationHeight: 10, CompletionTime: completion, Amount: 5}},
+			StubRewards:     []DelegationReward{{Validator: "cosmosvaloper1", Denom: "uatom", Amount: 1.5}},
+		}
+		var metrics mockAccountStakingMetrics
+
+		tasks := NewAccountStakingTasks(&metrics, &client, chain)
+		require.Len(t, tasks, 2)
+		require.Equal(t, "cosmoshub-4", tasks[1].Group())
+		require.Equal(t, "cosmos456-staking", tasks[1].ID())
+		require.Equal(t, time.Minute, tasks[1].Interval())
+
+		err := tasks[0].Run(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"cosmos123", "cosmos123", "cosmos123", "cosmos123"}, client.GotDelegators)
+		require.Equal(t, "cosmoshub-4", metrics.GotChain)
+		require.Equal(t, "treasury", metrics.GotAlias)
+		require.Equal(t, "cosmos123", metrics.GotAddress)
+		require.Equal(t, AccountStaking{
+			BondDenom:     "uatom",
+			Delegations:   client.StubDelegations,
+			Unbondings:    client.StubUnbondings,
+			Redelegations: nil,
+			Rewards:       client.StubRewards,
+		}, metrics.GotStaking)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		client := mockAccountStakingClient{RedelegationErr: errors.New("boom")}
+		var metrics mockAccountStakingMetrics
+
+		tasks := NewAccountStakingTasks(&metrics, &client, chain)
+		err := tasks[0].Run(context.Background())
+
+		require.EqualError(t, err, "redelegations: boom")
+		require.Zero(t, metrics.Calls)
+	})
+}
@@ -0,0 +1,166 @@
+package cosmos
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type BlockCacheMetrics interface {
+	IncBlockCacheHit(chain string)
+	IncBlockCacheMiss(chain string)
+}
+
+// BlockClient fetches the latest block or a block at a specific height.
+type BlockClient interface {
+	Client
+	BlockByHeight(ctx context.Context, height int64) (Block, error)
+}
+
+// maxCachedHeights is how many heights behind the highest seen height BlockCache keeps.
+const maxCachedHeights = 2 * maxTrackedHeights
+
+// BlockCache shares blocks amongst all tasks for a chain. Without it, every task polling the same chain
+// queries the latest block independently which quickly leads to rate limiting.
+// BlockCache satisfies BlockClient.
+type BlockCache struct {
+	chainID string
+	client  BlockClient
+	metrics BlockCacheMetrics
+	now     func() time.Time
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	latest    *blockCall
+	heights   map[int64]*blockCall
+	maxHeight int64
+}
+
+type blockCall struct {
+	done      chan struct{}
+	fetchedAt time.Time // Guarded by BlockCache.mu. Zero while in flight.
+	block     Block
+	err       error
+}
+
+func (call *blockCall) wait(ctx context.Context) (Block, error) {
+	select {
+	case <-ctx.Done():
+		return Block{}, ctx.Err()
+	case <-call.done:
+		return call.block, call.err
+	}
+}
+
+// NewBlockCache returns a cache for a single chain. The latest block is considered fresh for half
+// the chain's interval, so tasks running at the same interval share one request per interval.
+// Blocks by height never change, so they are cached until they fall too far behind the chain tip.
+func NewBlockCache(metrics BlockCacheMetrics, client BlockClient, chain Chain) *BlockCache {
+	return &BlockCache{
+		chainID: chain.ChainID,
+		client:  client,
+		metrics: metrics,
+		now:     time.Now,
+		ttl:     intervalOrDefault(chain.Interval) / 2,
+		heights: make(map[int64]*blockCall),
+	}
+}
+
+// LatestBlock returns the latest block, querying the underlying client at most once per ttl.
+// Concurrent callers share a single in-flight request. Each caller stops waiting when its context is done.
+// Errors are not cached.
+func (c *BlockCache) LatestBlock(ctx context.Context) (Block, error) {
+	c.mu.Lock()
+	if call := c.latest; call != nil && (call.fetchedAt.IsZero() || c.now().Sub(call.fetchedAt) < c.ttl) {
+		c.mu.Unlock()
+		c.metrics.IncBlockCacheHit(c.chainID)
+		return call.wait(ctx)
+	}
+	call := &blockCall{done: make(chan struct{})}
+	c.latest = call
+	c.mu.Unlock()
+	c.metrics.IncBlockCacheMiss(c.chainID)
+
+	go func() {
+		sharedCtx, cancel := sharedContext(ctx)
+		defer cancel()
+		call.block, call.err = c.client.LatestBlock(sharedCtx)
+
+		c.mu.Lock()
+		call.fetchedAt = c.now()
+		if call.err != nil && c.latest == call {
+			c.latest = nil
+		}
+		if call.err == nil {
+			if height, err := call.block.Height(); err == nil {
+				if _, ok := c.heights[height]; !ok {
+					c.heights[height] = call
+				}
+				c.prune(height)
+			}
+		}
+		c.mu.Unlock()
+		close(call.done)
+	}()
+
+	return call.wait(ctx)
+}
+
+// BlockByHeight returns the block at height, querying the underlying client at most once per height.
+// Concurrent callers share a single in-flight request. Each caller stops waiting when its context is done.
+// Errors are not cached.
+func (c *BlockCache) BlockByHeight(ctx context.Context, height int64) (Block, error) {
+	c.mu.Lock()
+	if call, ok := c.heights[height]; ok {
+		c.mu.Unlock()
+		c.metrics.IncBlockCacheHit(c.chainID)
+		return call.wait(ctx)
+	}
+	call := &blockCall{done: make(chan struct{})}
+	c.heights[height] = call
+	c.mu.Unlock()
+	c.metrics.IncBlockCacheMiss(c.chainID)
+
+	go func() {
+		sharedCtx, cancel := sharedContext(ctx)
+		defer cancel()
+		call.block, call.err = c.client.BlockByHeight(sharedCtx, height)
+
+		c.mu.Lock()
+		call.fetchedAt = c.now()
+		if call.err != nil && c.heights[height] == call {
+			delete(c.heights, height)
+		}
+		if call.err == nil {
+			c.prune(height)
+		}
+		c.mu.Unlock()
+		close(call.done)
+	}()
+
+	return call.wait(ctx)
+}
+
+// sharedContext returns a context for a request shared by concurrent callers. It is detached from ctx, so
+// waiting callers are not failed when the caller that started the request gives up, but keeps the time
+// budget of ctx. Without a deadline, the request is limited to defaultRequestTimeout.
+func sharedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := defaultRequestTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout)
+}
+
+// prune evicts heights too far behind the highest seen height. Caller must hold c.mu.
+func (c *BlockCache) prune(height int64) {
+	if height <= c.maxHeight {
+		return
+	}
+	c.maxHeight = height
+	for h := range c.heights {
+		if h <= c.maxHeight-maxCachedHeights {
+			delete(c.heights, h)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockBlockCacheMetrics struct {
+	Hits   int64
+	Misses int64
+}
+
+func (m *mockBlockCacheMetrics) IncBlockCacheHit(chain string) {
+	if chain != "cosmoshub-4" {
+		panic("unexpected chain: " + chain)
+	}
+	atomic.AddInt64(&m.Hits, 1)
+}
+
+func (m *mockBlockCacheMetrics) IncBlockCacheMiss(chain string) {
+	if chain != "cosmoshub-4" {
+		panic("unexpected chain: " + chain)
+	}
+	atomic.AddInt64(&m.Misses, 1)
+}
+
+type mockBlockClient struct {
+	CallCount int64
+	Release   chan struct{}
+	StubBlock Block
+	StubErr   error
+
+	GotHeights []int64
+}
+
+func (m *mockBlockClient) LatestBlock(ctx context.Context) (Block, error) {
+	atomic.AddInt64(&m.CallCount, 1)
+	if m.Release != nil {
+		select {
+		case <-ctx.Done():
+			return Block{}, ctx.Err()
+		case <-m.Release:
+		}
+	}
+	return m.StubBlock, m.StubErr
+}
+
+func (m *mockBlockClient) BlockByHeight(ctx context.Context, height int64) (Block, error) {
+	atomic.AddInt64(&m.CallCount, 1)
+	m.GotHeights = append(m.GotHeights, height)
+	var blk Block
+	blk.Block.Header.Height = strconv.FormatInt(height, 10)
+	return blk, m.StubErr
+}
+
+func TestBlockCache_LatestBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := Chain{ChainID: "cosmoshub-4", Interval: 10 * time.Second}
+
+	t.Run("happy path", func(t *testing.T) {
+		var client mockBlockClient
+		client.StubBlock.Block.Header.Height = "100"
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			got, err := cache.LatestBlock(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "100", got.Block.Header.Height)
+		}
+
+		require.EqualValues(t, 1, client.CallCount)
+		require.EqualValues(t, 1, metrics.Misses)
+		require.EqualValues(t, 2, metrics.Hits)
+
+		// Expire the cache
+		now = now.Add(5 * time.Second)
+		client.StubBlock.Block.Header.Height = "101"
+
+		got, err := cache.LatestBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "101", got.Block.Header.Height)
+		require.EqualValues(t, 2, client.CallCount)
+		require.EqualValues(t, 2, metrics.Misses)
+	})
+
+	t.Run("concurrent callers share request", func(t *testing.T) {
+		client := mockBlockClient{Release: make(chan struct{})}
+		client.StubBlock.Block.Header.Height = "100"
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		// Start the first request, which blocks until released.
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cache.LatestBlock(ctx)
+		}()
+		require.Eventually(t, func() bool { return atomic.LoadInt64(&client.CallCount) == 1 }, time.Second, time.Millisecond)
+
+		const waiters = 10
+		got := make([]Block, waiters)
+		for i := 0; i < waiters; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got[i], _ = cache.LatestBlock(ctx)
+			}()
+		}
+		require.Eventually(t, func() bool { return atomic.LoadInt64(&metrics.Hits) == waiters }, time.Second, time.Millisecond)
+
+		close(client.Release)
+		wg.Wait()
+
+		require.EqualValues(t, 1, client.CallCount)
+		require.EqualValues(t, 1, metrics.Misses)
+		for _, blk := range got {
+			require.Equal(t, "100", blk.Block.Header.Height)
+		}
+	})
+
+	t.Run("waiter context canceled", func(t *testing.T) {
+		client := mockBlockClient{Release: make(chan struct{})}
+		defer close(client.Release)
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		go func() { _, _ = cache.LatestBlock(ctx) }()
+		require.Eventually(t, func() bool { return atomic.LoadInt64(&client.CallCount) == 1 }, time.Second, time.Millisecond)
+
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := cache.LatestBlock(cctx)
+
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("first caller canceled", func(t *testing.T) {
+		client := mockBlockClient{Release: make(chan struct{})}
+		client.StubBlock.Block.Header.Height = "100"
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		cctx, cancel := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := cache.LatestBlock(cctx)
+			errCh <- err
+		}()
+		require.Eventually(t, func() bool { return atomic.LoadInt64(&client.CallCount) == 1 }, time.Second, time.Millisecond)
+
+		var (
+			got Block
+			err error
+		)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			got, err = cache.LatestBlock(ctx)
+		}()
+		require.Eventually(t, func() bool { return atomic.LoadInt64(&metrics.Hits) == 1 }, time.Second, time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-errCh, context.Canceled)
+
+		close(client.Release)
+		<-done
+		require.NoError(t, err)
+		require.Equal(t, "100", got.Block.Header.Height)
+		require.EqualValues(t, 1, client.CallCount)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		client := mockBlockClient{StubErr: errors.New("boom")}
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		_, err := cache.LatestBlock(ctx)
+		require.EqualError(t, err, "boom")
+
+		client.StubErr = nil
+		_, err = cache.LatestBlock(ctx)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 2, client.CallCount)
+		require.EqualValues(t, 2, metrics.Misses)
+	})
+}
+
+func TestBlockCache_BlockByHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := Chain{ChainID: "cosmoshub-4"}
+
+	t.Run("happy path", func(t *testing.T) {
+		var client mockBlockClient
+		client.StubBlock.Block.Header.Height = "100"
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		for _, h := range []int64{98, 99, 98, 99} {
+			got, err := cache.BlockByHeight(ctx, h)
+			require.NoError(t, err)
+			require.Equal(t, strconv.FormatInt(h, 10), got.Block.Header.Height)
+		}
+		require.Equal(t, []int64{98, 99}, client.GotHeights)
+
+		// The latest block is shared with heights.
+		_, err := cache.LatestBlock(ctx)
+		require.NoError(t, err)
+		got, err := cache.BlockByHeight(ctx, 100)
+		require.NoError(t, err)
+		require.Equal(t, "100", got.Block.Header.Height)
+
+		require.EqualValues(t, 3, client.CallCount)
+		require.EqualValues(t, 3, metrics.Misses)
+		require.EqualValues(t, 3, metrics.Hits)
+	})
+
+	t.Run("prunes old heights", func(t *testing.T) {
+		var client mockBlockClient
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		_, err := cache.BlockByHeight(ctx, 1)
+		require.NoError(t, err)
+		_, err = cache.BlockByHeight(ctx, 1+maxCachedHeights)
+		require.NoError(t, err)
+
+		require.Len(t, cache.heights, 1)
+
+		_, err = cache.BlockByHeight(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 1 + maxCachedHeights, 1}, client.GotHeights)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		client := mockBlockClient{StubErr: errors.New("boom")}
+		var metrics mockBlockCacheMetrics
+
+		cache := NewBlockCache(&metrics, &client, chain)
+
+		_, err := cache.BlockByHeight(ctx, 1)
+		require.EqualError(t, err, "boom")
+
+		client.StubErr = nil
+		_, err = cache.BlockByHeight(ctx, 1)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 2, client.CallCount)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"golang.org/x/exp/slog"
@@ -12,6 +13,9 @@ import (
 const (
 	defaultInterval       = 15 * time.Second
 	defaultRequestTimeout = 5 * time.Second
+
+	// blockIntervalSamples is how many observed blocks are used for the rolling average block interval.
+	blockIntervalSamples = 20
 )
 
 func intervalOrDefault(dur time.Duration) time.Duration {
@@ -24,6 +28,9 @@ func intervalOrDefault(dur time.Duration) time.Duration {
 // Metrics records metrics for Cosmos chains.
 type Metrics interface {
 	SetNodeHeight(chain string, height float64)
+	SetLatestBlockTime(chain string, timestamp float64)
+	SetSecondsSinceLastBlock(chain string, seconds float64)
+	SetAvgBlockInterval(chain string, seconds float64)
 }
 
 type Client interface {
@@ -31,11 +38,61 @@ type Client interface {
 }
 
 // BlockHeightTask queries the Cosmos REST (aka LCD) API for data and records various metrics.
+// It records:
+// - the latest block height and timestamp
+// - the seconds since the latest block, which detects halted chains
+// - the rolling average interval between blocks
 type BlockHeightTask struct {
 	chainID  string
 	client   Client
 	interval time.Duration
 	metrics  Metrics
+	now      func() time.Time
+
+	// Pointer because the task is passed by value but must remember observed blocks between runs.
+	samples *blockSamples
+}
+
+type blockSample struct {
+	height int64
+	time   time.Time
+}
+
+type blockSamples struct {
+	mu      sync.Mutex
+	samples []blockSample
+}
+
+// add records a block if it is newer than previously observed blocks and returns the average interval
+// between observed blocks. Returns false if there are not enough samples.
+func (s *blockSamples) add(sample blockSample) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := len(s.samples); n == 0 || sample.height > s.samples[n-1].height {
+		s.samples = append(s.samples, sample)
+	}
+	if len(s.samples) > blockIntervalSamples {
+		s.samples = s.samples[len(s.samples)-blockIntervalSamples:]
+	}
+	return s.avgLocked()
+}
+
+// avg returns the average interval between observed blocks. Returns false if there are not enough samples.
+func (s *blockSamples) avg() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.avgLocked()
+}
+
+func (s *blockSamples) avgLocked() (time.Duration, bool) {
+	if len(s.samples) == 0 {
+		return 0, false
+	}
+	first, last := s.samples[0], s.samples[len(s.samples)-1]
+	if last.height <= first.height {
+		return 0, false
+	}
+	return last.time.Sub(first.time) / time.Duration(last.height-first.height), true
 }
 
 func (task BlockHeightTask) Group() string { return task.chainID }
@@ -47,6 +104,8 @@ func NewBlockHeightTask(metrics Metrics, client Client, chain Chain) BlockHeight
 		client:   client,
 		interval: intervalOrDefault(chain.Interval),
 		metrics:  metrics,
+		now:      time.Now,
+		samples:  new(blockSamples),
 	}
 }
 
@@ -55,6 +114,12 @@ func (task BlockHeightTask) Interval() time.Duration {
 	return intervalOrDefault(task.interval)
 }
 
+// AvgBlockInterval returns the rolling average interval between blocks observed by the task.
+// Returns false until the task has observed at least two blocks.
+func (task BlockHeightTask) AvgBlockInterval() (time.Duration, bool) {
+	return task.samples.avg()
+}
+
 // Run queries the Endpoint server for data and records various metrics.
 func (task BlockHeightTask) Run(ctx context.Context) error {
 	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
@@ -66,10 +131,20 @@ func (task BlockHeightTask) Run(ctx context.Context) error {
 	if chainID := block.Block.Header.ChainID; chainID != task.chainID {
 		slog.Warn("Mismatched cosmos chain id", "expected", task.chainID, "actual", chainID)
 	}
-	height, err := strconv.ParseFloat(block.Block.Header.Height, 64)
+	height, err := strconv.ParseInt(block.Block.Header.Height, 10, 64)
 	if err != nil {
 		return fmt.Errorf("parse height: %w", err)
 	}
-	task.metrics.SetNodeHeight(task.chainID, height)
+	task.metrics.SetNodeHeight(task.chainID, float64(height))
+
+	blockTime := block.Block.Header.Time
+	if blockTime.IsZero() {
+		return nil
+	}
+	task.metrics.SetLatestBlockTime(task.chainID, float64(blockTime.UnixNano())/float64(time.Second))
+	task.metrics.SetSecondsSinceLastBlock(task.chainID, task.now().Sub(blockTime).Seconds())
+	if avg, ok := task.samples.add(blockSample{height: height, time: blockTime}); ok {
+		task.metrics.SetAvgBlockInterval(task.chainID, avg.Seconds())
+	}
 	return nil
 }
@@ -11,6 +11,10 @@ import (
 type mockCosmosMetrics struct {
 	NodeHeightChain string
 	NodeHeight      float64
+
+	LatestBlockTime       float64
+	SecondsSinceLastBlock float64
+	AvgBlockInterval      float64
 }
 
 func (m *mockCosmosMetrics) SetNodeHeight(chain string, height float64) {
@@ -18,6 +22,18 @@ func (m *mockCosmosMetrics) SetNodeHeight(chain string, height float64) {
 	m.NodeHeight = height
 }
 
+func (m *mockCosmosMetrics) SetLatestBlockTime(chain string, timestamp float64) {
+	m.LatestBlockTime = timestamp
+}
+
+func (m *mockCosmosMetrics) SetSecondsSinceLastBlock(chain string, seconds float64) {
+	m.SecondsSinceLastBlock = seconds
+}
+
+func (m *mockCosmosMetrics) SetAvgBlockInterval(chain string, seconds float64) {
+	m.AvgBlockInterval = seconds
+}
+
 type mockRestClient struct {
 	StubBlock Block
 }
@@ -67,4 +83,44 @@ func TestRestTask_Run(t *testing.T) {
 		require.Equal(t, float64(1234567890), metrics.NodeHeight)
 		require.Equal(t, "cosmoshub-4", metrics.NodeHeightChain)
 	})
+
+	t.Run("block time", func(t *testing.T) {
+		var client mockRestClient
+		chain := Chain{ChainID: "cosmoshub-4"}
+
+		var metrics mockCosmosMetrics
+		task := NewBlockHeightTask(&metrics, &client, chain)
+		start := time.Unix(1700000000, 0)
+		now := start.Add(3 * time.Second)
+		task.now = func() time.Time { return now }
+
+		for _, tt := range []struct {
+			Height  string
+			Offset  time.Duration
+			WantAvg float64
+		}{
+			{"100", 0, 0},
+			{"101", 6 * time.Second, 6},
+			// Same block observed twice is ignored.
+			{"101", 6 * time.Second, 6},
+			{"103", 18 * time.Second, 6},
+			{"104", 30 * time.Second, 7.5},
+		} {
+			client.StubBlock.Block.Header.ChainID = "cosmoshub-4"
+			client.StubBlock.Block.Header.Height = tt.Height
+			client.StubBlock.Block.Header.Time = start.Add(tt.Offset)
+			now = start.Add(tt.Offset + 3*time.Second)
+
+			err := task.Run(ctx)
+			require.NoError(t, err)
+
+			require.Equal(t, float64(start.Add(tt.Offset).Unix()), metrics.LatestBlockTime, tt)
+			require.Equal(t, 3.0, metrics.SecondsSinceLastBlock, tt)
+			require.Equal(t, tt.WantAvg, metrics.AvgBlockInterval, tt)
+
+			avg, ok := task.AvgBlockInterval()
+			require.Equal(t, tt.WantAvg > 0, ok, tt)
+			require.Equal(t, tt.WantAvg, avg.Seconds(), tt)
+		}
+	})
 }
@@ -1,13 +1,50 @@
 package cosmos
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Chain struct {
 	ChainID string
 	// Interval is how often to poll the endpoints for data.
 	Interval time.Duration
+	// TrackHeights processes every block height since the previous poll when recording validator signed
+	// and missed blocks. Otherwise, only the latest block at poll time is processed.
+	// Costs one additional request per block, but no heights are skipped.
+	TrackHeights bool
 	// Rest are the Cosmos REST (aka LCD) endpoints to poll for data.
-	Rest       []Endpoint
+	Rest []Endpoint
+	// MaxBlockLag is how many blocks a REST endpoint may fall behind the highest REST endpoint before it is
+	// demoted. Demoted endpoints are only used if all other endpoints fail. Requires at least two REST endpoints.
+	// Defaults to 20.
+	MaxBlockLag int64
+	// RPC are the CometBFT RPC endpoints to poll for data, typically on port 26657.
+	// If set, block data is queried from the RPC instead of REST because it is cheaper.
+	// Without REST or gRPC endpoints, validator and account data is also queried from the RPC.
+	// Gov, upgrade and account staking metrics require REST endpoints.
+	RPC []Endpoint
+	// GRPC are the Cosmos gRPC endpoints to poll for data, typically on port 9090.
+	// Use the https scheme for TLS, e.g. https://grpc.example.com:443, otherwise http, e.g. http://localhost:9090.
+	// If set, validator and account data is queried from gRPC instead of REST. Block data is queried from gRPC
+	// unless RPC endpoints are set. Gov, upgrade and account staking metrics require REST endpoints.
+	GRPC []Endpoint
+	// Stream subscribes to new blocks over the websocket of the RPC endpoints, so validator signed and missed
+	// blocks are recorded as soon as blocks are produced. Requires RPC endpoints.
+	// If the websocket disconnects, blocks are polled until it reconnects.
+	Stream bool
+	// Retry configures retries of transient failures, such as 429 and 503 responses, for all endpoints.
+	// By default, a failed request immediately falls back to the next endpoint.
+	Retry Retry
+	// CacheTTLs overrides how long responses of REST paths are cached, keyed by path template,
+	// e.g. /cosmos/slashing/v1beta1/params. A TTL of 0 disables caching of the path. See RestCacheTTLs for defaults.
+	// If all REST endpoints fail, cached responses are served regardless of their age.
+	CacheTTLs map[string]time.Duration
+	// TLS is the default TLS profile for endpoints without their own.
+	TLS TLS
+	// Proxy routes requests to REST, RPC and gRPC endpoints through a proxy, e.g. http://proxy:3128 or socks5://proxy:1080.
+	// Overrides the global proxy. Endpoints may override it.
+	Proxy      string
 	Accounts   []Account
 	Validators []Validator
 }
@@ -15,15 +52,87 @@ type Chain struct {
 type Account struct {
 	Address string
 	// Alias is a human-readable name for the account, e.g. cosmoshub-validator.
-	Alias  string
+	Alias string
+	// Denoms are the balances to query. If the account has no balance of a denom, the balance is 0.
+	// If empty or ["*"], all non-zero balances are queried and balances that drop to zero are removed.
 	Denoms []string
+	// IncludeDenoms is a regular expression of denoms to include when querying all balances, e.g. ^u.
+	// Defaults to all denoms.
+	IncludeDenoms string
+	// ExcludeDenoms is a regular expression of denoms to exclude when querying all balances, e.g. ^ibc/
+	// to skip IBC dust. Applied after IncludeDenoms.
+	ExcludeDenoms string
+}
+
+// AllDenoms returns true if all balances of the account are queried instead of specific denoms.
+func (a Account) AllDenoms() bool {
+	return len(a.Denoms) == 0 || slices.Contains(a.Denoms, "*")
 }
 
 type Validator struct {
 	// The validator's consensus address. Example prefix: cosmosvalcons...
+	// Optional if Valoper is set.
 	ConsAddress string
+	// The validator's operator address. Required for staking metrics. Example prefix: cosmosvaloper...
+	// Blocks are only counted as missed while the validator is bonded. Without an operator address, blocks are
+	// counted as missed unless the validator is jailed.
+	// If set, the consensus address is resolved from the chain. If ConsAddress is also set and does
+	// not match, the resolved address is used and the mismatch is logged and recorded.
+	Valoper string
+}
+
+// Retry configures retries of transient failures before falling back to the next endpoint.
+// Mapped to a metrics.RetryPolicy by retryPolicy in cmd/root.go.
+type Retry struct {
+	// MaxAttempts is the number of attempts per endpoint, including the first request. Defaults to 1, i.e. no retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with each subsequent retry and is randomized.
+	// A longer Retry-After from the endpoint is honored. Defaults to 500ms.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries. Defaults to 5s.
+	MaxBackoff time.Duration
 }
 
 type Endpoint struct {
 	URL string
+	// Headers are added to every request to the endpoint, e.g. x-api-key for a paid provider.
+	// Values may be secrets loaded from the environment or a file. See BasicAuth.
+	Headers map[string]string
+	// BearerToken is sent in the Authorization header. May be a secret. Mutually exclusive with BasicAuth.
+	BearerToken string
+	// BasicAuth is sent in the Authorization header if Username is set. Credentials in the URL also work,
+	// but keep secrets out of the config file.
+	BasicAuth BasicAuth
+	// RequestsPerSecond limits requests to the endpoint, e.g. to avoid bans from public endpoints.
+	// Requests wait for the limit rather than fail. Defaults to no limit.
+	RequestsPerSecond float64
+	// Burst is how many requests may be sent at once before RequestsPerSecond applies.
+	// Defaults to RequestsPerSecond rounded up.
+	Burst int
+	// TLS overrides the chain's TLS profile. Applies to endpoints using the https scheme.
+	TLS TLS
+	// Proxy overrides the chain's proxy.
+	Proxy string
+}
+
+// TLS configures connections to endpoints, e.g. for a private CA or mutual TLS.
+// The zero value uses the system's root certificates.
+type TLS struct {
+	// CAFile is a PEM encoded CA bundle used instead of the system's root certificates.
+	CAFile string
+	// CertFile and KeyFile are a PEM encoded client certificate and key for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the server name used to verify the certificate, e.g. when connecting by IP address.
+	ServerName string
+	// InsecureSkipVerify disables certificate verification. Only use for testing.
+	InsecureSkipVerify bool
+}
+
+// BasicAuth credentials. Secret values are prefixed with "env:" to read an environment variable,
+// e.g. env:API_PASSWORD, or "file:" to read a file, e.g. file:/run/secrets/api-password.
+// Other values are used as is.
+type BasicAuth struct {
+	Username string
+	Password string
 }
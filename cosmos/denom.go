@@ -0,0 +1,131 @@
+package cosmos
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// DenomClient queries denom metadata. Implemented by the REST client.
+type DenomClient interface {
+	DenomsMetadata(ctx context.Context) (map[string]DenomMetadata, error)
+	DenomTrace(ctx context.Context, hash string) (DenomTrace, error)
+	IBCDenom(ctx context.Context, hash string) (DenomTrace, error)
+}
+
+// unresolvedDenomTTL is how long an IBC denom that neither denom traces nor denoms could resolve is not queried
+// again. Otherwise, every run would query all hosts for it.
+const unresolvedDenomTTL = time.Hour
+
+// denomResolver resolves the metadata of denoms. Safe for concurrent use. A nil resolver sets the base denom
+// to the denom.
+type denomResolver struct {
+	chainID string
+	client  DenomClient
+	now     func() time.Time
+
+	mu         sync.Mutex
+	baseDenoms map[string]string    // IBC denom hash -> base denom on the source chain. Never changes.
+	unresolved map[string]time.Time // IBC denom hash -> when to query it again
+}
+
+// newDenomResolver returns nil if client is nil.
+func newDenomResolver(chainID string, client DenomClient) *denomResolver {
+	if client == nil {
+		return nil
+	}
+	return &denomResolver{
+		chainID:    chainID,
+		client:     client,
+		now:        time.Now,
+		baseDenoms: make(map[string]string),
+		unresolved: make(map[string]time.Time),
+	}
+}
+
+// resolve sets the metadata of the balances. IBC denoms are resolved to the base denom on the source chain.
+// Metadata is looked up by denom, then by the resolved base denom. Chains rarely register metadata for either,
+// so most IBC balances have no display denom.
+// Failures are logged rather than returned so balances are always recorded. The balance of a denom that
+// failed to resolve has the denom as the base denom and no display denom.
+func (r *denomResolver) resolve(ctx context.Context, balances []AccountBalance) {
+	if r == nil {
+		for i := range balances {
+			balances[i].Metadata = DenomMetadata{Base: balances[i].Denom}
+		}
+		return
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	metadata, err := r.client.DenomsMetadata(cctx)
+	if err != nil {
+		slog.Warn("Failed to query denom metadata", "chain", r.chainID, "error", err)
+	}
+
+	for i, bal := range balances {
+		base := bal.Denom
+		if hash, ok := strings.CutPrefix(bal.Denom, "ibc/"); ok {
+			resolved, err := r.baseDenom(ctx, hash)
+			if err != nil {
+				slog.Warn("Failed to resolve IBC denom", "chain", r.chainID, "denom", bal.Denom, "error", err)
+				balances[i].Metadata = DenomMetadata{Base: bal.Denom}
+				continue
+			}
+			if resolved != "" {
+				base = resolved
+			}
+		}
+
+		md, ok := metadata[bal.Denom]
+		if !ok {
+			md = metadata[base]
+		}
+		md.Base = base
+		balances[i].Metadata = md
+	}
+}
+
+// baseDenom returns the base denom of an IBC denom hash, or an empty string if the chain cannot resolve it.
+func (r *denomResolver) baseDenom(ctx context.Context, hash string) (string, error) {
+	r.mu.Lock()
+	base, ok := r.baseDenoms[hash]
+	retryAt, unresolved := r.unresolved[hash]
+	r.mu.Unlock()
+	switch {
+	case ok:
+		return base, nil
+	case unresolved && r.now().Before(retryAt):
+		return "", nil
+	}
+
+	// Newer versions of ibc-go replaced denom traces with denoms.
+	for _, lookup := range []func(context.Context, string) (DenomTrace, error){r.client.DenomTrace, r.client.IBCDenom} {
+		cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+		trace, err := lookup(cctx, hash)
+		cancel()
+		switch {
+		case hasStatusCode(err, http.StatusBadRequest, http.StatusNotFound, http.StatusNotImplemented):
+			continue
+		case err != nil:
+			return "", err
+		case trace.BaseDenom == "":
+			continue
+		}
+
+		r.mu.Lock()
+		r.baseDenoms[hash] = trace.BaseDenom
+		delete(r.unresolved, hash)
+		r.mu.Unlock()
+		return trace.BaseDenom, nil
+	}
+
+	r.mu.Lock()
+	r.unresolved[hash] = r.now().Add(unresolvedDenomTTL)
+	r.mu.Unlock()
+	return "", nil
+}
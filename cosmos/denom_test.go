@@ -0,0 +1,171 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockDenomClient struct {
+	StubMetadata map[string]DenomMetadata
+	MetadataErr  error
+	StubTraces   map[string]DenomTrace
+	TraceErr     error
+	StubDenoms   map[string]DenomTrace
+	DenomErr     error
+
+	TraceCalls atomic.Int64
+	DenomCalls atomic.Int64
+}
+
+func (m *mockDenomClient) DenomsMetadata(ctx context.Context) (map[string]DenomMetadata, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		panic("no deadline")
+	}
+	return m.StubMetadata, m.MetadataErr
+}
+
+func (m *mockDenomClient) DenomTrace(ctx context.Context, hash string) (DenomTrace, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		panic("no deadline")
+	}
+	m.TraceCalls.Add(1)
+	return m.StubTraces[hash], m.TraceErr
+}
+
+func (m *mockDenomClient) IBCDenom(ctx context.Context, hash string) (DenomTrace, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		panic("no deadline")
+	}
+	m.DenomCalls.Add(1)
+	return m.StubDenoms[hash], m.DenomErr
+}
+
+func TestDenomResolver_Resolve(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockDenomClient{
+			StubMetadata: map[string]DenomMetadata{
+				"uosmo":   {Base: "uosmo", Display: "osmo", Exponent: 6},
+				"ibc/ABC": {Base: "ibc/ABC", Display: "atom", Exponent: 6},
+				"uakt":    {Base: "uakt", Display: "akt", Exponent: 6},
+			},
+			StubTraces: map[string]DenomTrace{
+				"ABC": {Path: "transfer/channel-0", BaseDenom: "uatom"},
+				"DEF": {Path: "transfer/channel-1", BaseDenom: "ujuno"},
+				"GHI": {Path: "transfer/channel-2", BaseDenom: "uakt"},
+			},
+		}
+		balances := []AccountBalance{
+			{Denom: "uosmo", Amount: 1},
+			{Denom: "ibc/ABC", Amount: 2},
+			{Denom: "ibc/DEF", Amount: 3},
+			{Denom: "factory/osmo1/token", Amount: 4},
+			{Denom: "ibc/GHI", Amount: 5},
+		}
+
+		newDenomResolver("osmosis-1", client).resolve(ctx, balances)
+
+		require.Equal(t, []DenomMetadata{
+			{Base: "uosmo", Display: "osmo", Exponent: 6},
+			{Base: "uatom", Display: "atom", Exponent: 6},
+			{Base: "ujuno"},
+			{Base: "factory/osmo1/token"},
+			// Metadata is looked up by the base denom if the chain has none for the IBC denom.
+			{Base: "uakt", Display: "akt", Exponent: 6},
+		}, []DenomMetadata{balances[0].Metadata, balances[1].Metadata, balances[2].Metadata, balances[3].Metadata, balances[4].Metadata})
+
+		amount, ok := balances[1].DisplayAmount()
+		require.True(t, ok)
+		require.Equal(t, 0.000002, amount)
+		_, ok = balances[2].DisplayAmount()
+		require.False(t, ok)
+		require.Zero(t, client.DenomCalls.Load())
+	})
+
+	t.Run("falls back to denoms", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockDenomClient{
+			TraceErr:   mockStatusError(http.StatusNotImplemented),
+			StubDenoms: map[string]DenomTrace{"ABC": {Path: "transfer/channel-0", BaseDenom: "uatom"}},
+		}
+		resolver := newDenomResolver("osmosis-1", client)
+		balances := []AccountBalance{{Denom: "ibc/ABC", Amount: 1}}
+
+		resolver.resolve(ctx, balances)
+		require.Equal(t, DenomMetadata{Base: "uatom"}, balances[0].Metadata)
+
+		// Base denoms never change, so they are not queried again.
+		resolver.resolve(ctx, balances)
+		require.Equal(t, DenomMetadata{Base: "uatom"}, balances[0].Metadata)
+		require.EqualValues(t, 1, client.TraceCalls.Load())
+		require.EqualValues(t, 1, client.DenomCalls.Load())
+	})
+
+	t.Run("unresolved", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockDenomClient{
+			TraceErr: mockStatusError(http.StatusNotImplemented),
+			DenomErr: mockStatusError(http.StatusNotFound),
+		}
+		resolver := newDenomResolver("osmosis-1", client)
+		now := time.Now()
+		resolver.now = func() time.Time { return now }
+		balances := []AccountBalance{{Denom: "ibc/ABC", Amount: 1}}
+
+		resolver.resolve(ctx, balances)
+		require.Equal(t, DenomMetadata{Base: "ibc/ABC"}, balances[0].Metadata)
+
+		resolver.resolve(ctx, balances)
+		require.Equal(t, DenomMetadata{Base: "ibc/ABC"}, balances[0].Metadata)
+		require.EqualValues(t, 1, client.TraceCalls.Load())
+		require.EqualValues(t, 1, client.DenomCalls.Load())
+
+		now = now.Add(unresolvedDenomTTL)
+		resolver.resolve(ctx, balances)
+		require.EqualValues(t, 2, client.TraceCalls.Load())
+		require.EqualValues(t, 2, client.DenomCalls.Load())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockDenomClient{
+			StubMetadata: map[string]DenomMetadata{"ibc/ABC": {Base: "ibc/ABC", Display: "atom", Exponent: 6}},
+			MetadataErr:  errors.New("metadata boom"),
+			TraceErr:     errors.New("trace boom"),
+		}
+		resolver := newDenomResolver("osmosis-1", client)
+		balances := []AccountBalance{{Denom: "ibc/ABC", Amount: 1}, {Denom: "uosmo", Amount: 2}}
+
+		resolver.resolve(ctx, balances)
+		require.Equal(t, DenomMetadata{Base: "ibc/ABC"}, balances[0].Metadata)
+		require.Equal(t, DenomMetadata{Base: "uosmo"}, balances[1].Metadata)
+
+		// Errors are not cached.
+		resolver.resolve(ctx, balances)
+		require.EqualValues(t, 2, client.TraceCalls.Load())
+		require.Zero(t, client.DenomCalls.Load())
+	})
+
+	t.Run("nil client", func(t *testing.T) {
+		t.Parallel()
+
+		balances := []AccountBalance{{Denom: "uatom", Amount: 1}}
+
+		newDenomResolver("cosmoshub-4", nil).resolve(ctx, balances)
+		require.Equal(t, DenomMetadata{Base: "uatom"}, balances[0].Metadata)
+	})
+}
@@ -0,0 +1,127 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// defaultMaxBlockLag is how many blocks an endpoint may fall behind before it is demoted.
+const defaultMaxBlockLag = 20
+
+// EndpointMetrics records the freshness of individual endpoints.
+type EndpointMetrics interface {
+	SetEndpointHeight(chain string, host url.URL, height float64)
+	SetEndpointBlockLag(chain string, host url.URL, blocks float64)
+}
+
+// EndpointClient queries individual hosts and demotes hosts that lag behind. Implemented by metrics.FallbackClient.
+type EndpointClient interface {
+	Hosts() []url.URL
+	GetHost(ctx context.Context, host url.URL, path url.URL) (*http.Response, error)
+	SetLagging(host url.URL, lagging bool)
+}
+
+// EndpointHeightTask compares the latest block height of every REST endpoint of a chain.
+// An endpoint may respond successfully yet be far behind the chain tip, which makes other metrics stale.
+// Endpoints lagging the highest endpoint by more than the max block lag are demoted until they catch up.
+type EndpointHeightTask struct {
+	chainID  string
+	client   EndpointClient
+	interval time.Duration
+	maxLag   int64
+	metrics  EndpointMetrics
+}
+
+func NewEndpointHeightTask(metrics EndpointMetrics, client EndpointClient, chain Chain) EndpointHeightTask {
+	maxLag := chain.MaxBlockLag
+	if maxLag <= 0 {
+		maxLag = defaultMaxBlockLag
+	}
+	return EndpointHeightTask{
+		chainID:  chain.ChainID,
+		client:   client,
+		interval: intervalOrDefault(chain.Interval),
+		maxLag:   maxLag,
+		metrics:  metrics,
+	}
+}
+
+func (task EndpointHeightTask) Group() string { return task.chainID }
+func (task EndpointHeightTask) ID() string    { return "endpoint-height" }
+
+// Interval is how often to compare endpoint heights.
+func (task EndpointHeightTask) Interval() time.Duration {
+	return intervalOrDefault(task.interval)
+}
+
+// Run queries the latest block of every endpoint concurrently, records heights and lag, and demotes lagging endpoints.
+// Endpoints that fail are skipped, so their lagging status is unchanged.
+func (task EndpointHeightTask) Run(ctx context.Context) error {
+	hosts := task.client.Hosts()
+	heights := make([]int64, len(hosts))
+	errs := make([]error, len(hosts))
+
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			heights[i], errs[i] = task.latestHeight(ctx, host)
+		}()
+	}
+	wg.Wait()
+
+	var tip int64
+	for i := range hosts {
+		if errs[i] == nil && heights[i] > tip {
+			tip = heights[i]
+		}
+	}
+	if tip == 0 {
+		return fmt.Errorf("no endpoint returned a block height: %w", errors.Join(errs...))
+	}
+
+	for i, host := range hosts {
+		if errs[i] != nil {
+			slog.Debug("Failed to query endpoint height", "chain", task.chainID, "host", host.Hostname(), "error", errs[i])
+			continue
+		}
+		lag := tip - heights[i]
+		task.metrics.SetEndpointHeight(task.chainID, host, float64(heights[i]))
+		task.metrics.SetEndpointBlockLag(task.chainID, host, float64(lag))
+
+		lagging := lag > task.maxLag
+		if lagging {
+			slog.Warn("Endpoint is lagging behind, demoting", "chain", task.chainID, "host", host.Hostname(), "blocks_behind", lag)
+		}
+		task.client.SetLagging(host, lagging)
+	}
+	return nil
+}
+
+func (task EndpointHeightTask) latestHeight(ctx context.Context, host url.URL) (int64, error) {
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	block, err := NewRestClient(hostClient{client: task.client, host: host}).LatestBlock(cctx)
+	if err != nil {
+		return 0, err
+	}
+	return block.Height()
+}
+
+// hostClient sends requests to a single host of an EndpointClient.
+type hostClient struct {
+	client EndpointClient
+	host   url.URL
+}
+
+func (c hostClient) Get(ctx context.Context, path url.URL) (*http.Response, error) {
+	return c.client.GetHost(ctx, c.host, path)
+}
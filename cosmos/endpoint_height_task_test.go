@@ -0,0 +1,139 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockEndpointClient struct {
+	StubHosts  []url.URL
+	StubHeight map[string]int64
+	GotLagging map[string]bool
+}
+
+func (m *mockEndpointClient) Hosts() []url.URL { return m.StubHosts }
+
+func (m *mockEndpointClient) GetHost(ctx context.Context, host url.URL, path url.URL) (*http.Response, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	if path.Path != "/cosmos/base/tendermint/v1beta1/blocks/latest" {
+		panic(fmt.Errorf("unexpected path: %s", path.Path))
+	}
+	height, ok := m.StubHeight[host.Hostname()]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	body := fmt.Sprintf(`{"block":{"header":{"height":"%d"}}}`, height)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (m *mockEndpointClient) SetLagging(host url.URL, lagging bool) {
+	if m.GotLagging == nil {
+		m.GotLagging = make(map[string]bool)
+	}
+	m.GotLagging[host.Hostname()] = lagging
+}
+
+type mockEndpointMetrics struct {
+	GotHeight map[string]float64
+	GotLag    map[string]float64
+}
+
+func (m *mockEndpointMetrics) SetEndpointHeight(chain string, host url.URL, height float64) {
+	if chain != "cosmoshub-4" {
+		panic(chain)
+	}
+	if m.GotHeight == nil {
+		m.GotHeight = make(map[string]float64)
+	}
+	m.GotHeight[host.Hostname()] = height
+}
+
+func (m *mockEndpointMetrics) SetEndpointBlockLag(chain string, host url.URL, blocks float64) {
+	if m.GotLag == nil {
+		m.GotLag = make(map[string]float64)
+	}
+	m.GotLag[host.Hostname()] = blocks
+}
+
+func TestEndpointHeightTask_Interval(t *testing.T) {
+	t.Parallel()
+
+	task := NewEndpointHeightTask(nil, nil, Chain{Interval: time.Second})
+	require.Equal(t, time.Second, task.Interval())
+	require.EqualValues(t, defaultMaxBlockLag, task.maxLag)
+
+	task = NewEndpointHeightTask(nil, nil, Chain{MaxBlockLag: 5})
+	require.Equal(t, defaultInterval, task.Interval())
+	require.EqualValues(t, 5, task.maxLag)
+}
+
+func TestEndpointHeightTask_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	hosts := []url.URL{
+		{Scheme: "https", Host: "1.example.com"},
+		{Scheme: "https", Host: "2.example.com"},
+		{Scheme: "https", Host: "3.example.com"},
+	}
+	chain := Chain{ChainID: "cosmoshub-4", MaxBlockLag: 10}
+
+	t.Run("happy path", func(t *testing.T) {
+		client := &mockEndpointClient{
+			StubHosts: hosts,
+			StubHeight: map[string]int64{
+				"1.example.com": 89,
+				"2.example.com": 100,
+				"3.example.com": 90,
+			},
+		}
+		var metrics mockEndpointMetrics
+		task := NewEndpointHeightTask(&metrics, client, chain)
+
+		require.NoError(t, task.Run(ctx))
+
+		require.Equal(t, map[string]float64{"1.example.com": 89, "2.example.com": 100, "3.example.com": 90}, metrics.GotHeight)
+		require.Equal(t, map[string]float64{"1.example.com": 11, "2.example.com": 0, "3.example.com": 10}, metrics.GotLag)
+		require.Equal(t, map[string]bool{"1.example.com": true, "2.example.com": false, "3.example.com": false}, client.GotLagging)
+	})
+
+	t.Run("failed endpoint", func(t *testing.T) {
+		client := &mockEndpointClient{
+			StubHosts: hosts,
+			StubHeight: map[string]int64{
+				"2.example.com": 100,
+				"3.example.com": 50,
+			},
+		}
+		var metrics mockEndpointMetrics
+		task := NewEndpointHeightTask(&metrics, client, chain)
+
+		require.NoError(t, task.Run(ctx))
+
+		require.NotContains(t, metrics.GotHeight, "1.example.com")
+		require.Equal(t, map[string]bool{"2.example.com": false, "3.example.com": true}, client.GotLagging)
+	})
+
+	t.Run("all endpoints fail", func(t *testing.T) {
+		client := &mockEndpointClient{StubHosts: hosts}
+		var metrics mockEndpointMetrics
+		task := NewEndpointHeightTask(&metrics, client, chain)
+
+		err := task.Run(ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "connection refused")
+		require.Empty(t, client.GotLagging)
+	})
+}
@@ -0,0 +1,162 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"golang.org/x/exp/slog"
+)
+
+// Voter is an account that is expected to vote on governance proposals.
+type Voter struct {
+	// Address is the account address of the voter.
+	Address string
+	// Alias is a human-readable name for the voter. The operator address for validators.
+	Alias string
+}
+
+// ProposalVote is whether a voter has voted on a proposal.
+type ProposalVote struct {
+	Voter Voter
+	Voted bool
+}
+
+type GovMetrics interface {
+	// SetGovProposals replaces all proposal metrics for the chain, so proposals no longer in the
+	// voting period are removed.
+	SetGovProposals(chain string, proposals []Proposal, votes map[uint64][]ProposalVote)
+}
+
+type GovClient interface {
+	VotingProposals(ctx context.Context, version GovVersion) ([]Proposal, error)
+	HasVoted(ctx context.Context, version GovVersion, proposalID uint64, voter string) (bool, error)
+}
+
+// GovTask queries the gov module for proposals in the voting period and records metrics.
+// It records:
+// - the voting end time of each proposal
+// - whether each validator and account has voted
+//
+// The task prefers gov v1. If the chain does not support v1, it falls back to v1beta1.
+type GovTask struct {
+	chainID string
+	client  GovClient
+	metrics GovMetrics
+	voters  []Voter
+
+	// Pointer because the task is passed by value but must remember the detected version between runs.
+	version *govVersion
+}
+
+type govVersion struct {
+	mu      sync.Mutex
+	version GovVersion
+}
+
+func (v *govVersion) get() GovVersion {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.version
+}
+
+func (v *govVersion) set(version GovVersion) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.version = version
+}
+
+func NewGovTask(metrics GovMetrics, client GovClient, chain Chain) GovTask {
+	var voters []Voter
+	for _, val := range chain.Validators {
+		if val.Valoper == "" {
+			continue
+		}
+		addr, err := accountFromValoper(val.Valoper)
+		if err != nil {
+			slog.Warn("Cannot derive account address for validator, skipping gov votes", "chain", chain.ChainID, "valoper", val.Valoper, "error", err)
+			continue
+		}
+		voters = append(voters, Voter{Address: addr, Alias: val.Valoper})
+	}
+	for _, account := range chain.Accounts {
+		voters = append(voters, Voter{Address: account.Address, Alias: account.Alias})
+	}
+	return GovTask{
+		chainID: chain.ChainID,
+		client:  client,
+		metrics: metrics,
+		voters:  voters,
+		version: &govVersion{version: GovV1},
+	}
+}
+
+func (task GovTask) Group() string { return task.chainID }
+func (task GovTask) ID() string    { return "gov" }
+
+// Interval is hardcoded to a longer duration because voting periods last days.
+// Longer duration minimizes API calls to prevent hitting rate limits.
+func (task GovTask) Interval() time.Duration { return 5 * time.Minute }
+
+// Run records metrics for all proposals in the voting period.
+func (task GovTask) Run(ctx context.Context) error {
+	version := task.version.get()
+	proposals, err := task.votingProposals(ctx, version)
+	if err != nil && version == GovV1 && hasStatusCode(err, http.StatusNotFound, http.StatusNotImplemented) {
+		slog.Info("Gov v1 not supported, falling back to v1beta1", "chain", task.chainID)
+		version = GovV1Beta1
+		proposals, err = task.votingProposals(ctx, version)
+		if err == nil {
+			task.version.set(version)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	votes := make(map[uint64][]ProposalVote, len(proposals))
+	for _, proposal := range proposals {
+		for _, voter := range task.voters {
+			voted, err := task.hasVoted(ctx, version, proposal.ID, voter.Address)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("proposal %d voter %s: %w", proposal.ID, voter.Address, err))
+				continue
+			}
+			votes[proposal.ID] = append(votes[proposal.ID], ProposalVote{Voter: voter, Voted: voted})
+		}
+	}
+
+	task.metrics.SetGovProposals(task.chainID, proposals, votes)
+	return errors.Join(errs...)
+}
+
+func (task GovTask) votingProposals(ctx context.Context, version GovVersion) ([]Proposal, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	return task.client.VotingProposals(ctx, version)
+}
+
+func (task GovTask) hasVoted(ctx context.Context, version GovVersion, proposalID uint64, voter string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	return task.client.HasVoted(ctx, version, proposalID, voter)
+}
+
+// accountFromValoper converts an operator address to the validator's account address,
+// e.g. cosmosvaloper... becomes cosmos...
+func accountFromValoper(valoper string) (string, error) {
+	hrp, bz, err := bech32.DecodeAndConvert(valoper)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(hrp, "valoper") {
+		return "", fmt.Errorf("unexpected operator address prefix %q", hrp)
+	}
+	return bech32.ConvertAndEncode(strings.TrimSuffix(hrp, "valoper"), bz)
+}
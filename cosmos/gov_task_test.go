@@ -0,0 +1,151 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockGovClient struct {
+	StubProposals map[GovVersion][]Proposal
+	StubVoted     map[string]bool
+
+	GotVersions []GovVersion
+}
+
+func (m *mockGovClient) VotingProposals(ctx context.Context, version GovVersion) ([]Proposal, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	m.GotVersions = append(m.GotVersions, version)
+	proposals, ok := m.StubProposals[version]
+	if !ok {
+		return nil, mockStatusError(http.StatusNotImplemented)
+	}
+	return proposals, nil
+}
+
+func (m *mockGovClient) HasVoted(ctx context.Context, version GovVersion, proposalID uint64, voter string) (bool, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	voted, ok := m.StubVoted[voter]
+	if !ok {
+		return false, errors.New("boom")
+	}
+	return voted, nil
+}
+
+type mockGovMetrics struct {
+	GotChain     string
+	GotProposals []Proposal
+	GotVotes     map[uint64][]ProposalVote
+}
+
+func (m *mockGovMetrics) SetGovProposals(chain string, proposals []Proposal, votes map[uint64][]ProposalVote) {
+	m.GotChain = chain
+	m.GotProposals = proposals
+	m.GotVotes = votes
+}
+
+func TestGovTask_Interval(t *testing.T) {
+	t.Parallel()
+
+	task := NewGovTask(nil, nil, Chain{Interval: time.Second})
+
+	require.Equal(t, 5*time.Minute, task.Interval())
+}
+
+func TestGovTask_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const (
+		valoper    = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+		valAccount = "cosmos1xyerxdp4xcmnswfsxyerxdp4xcmnswfs2mnmda"
+	)
+	chain := Chain{
+		ChainID: "cosmoshub-4",
+		Validators: []Validator{
+			{ConsAddress: "cosmosvalcons123"},
+			{Valoper: valoper},
+		},
+		Accounts: []Account{
+			{Address: "cosmos456", Alias: "treasury"},
+		},
+	}
+	proposals := []Proposal{{ID: 1, Title: "Upgrade", VotingEndTime: time.Now().Add(time.Hour)}}
+
+	t.Run("happy path", func(t *testing.T) {
+		client := &mockGovClient{
+			StubProposals: map[GovVersion][]Proposal{GovV1: proposals},
+			StubVoted:     map[string]bool{valAccount: true, "cosmos456": false},
+		}
+		var metrics mockGovMetrics
+
+		task := NewGovTask(&metrics, client, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, "cosmoshub-4", metrics.GotChain)
+		require.Equal(t, proposals, metrics.GotProposals)
+		require.Equal(t, map[uint64][]ProposalVote{
+			1: {
+				{Voter: Voter{Address: valAccount, Alias: valoper}, Voted: true},
+				{Voter: Voter{Address: "cosmos456", Alias: "treasury"}, Voted: false},
+			},
+		}, metrics.GotVotes)
+	})
+
+	t.Run("falls back to v1beta1", func(t *testing.T) {
+		client := &mockGovClient{
+			StubProposals: map[GovVersion][]Proposal{GovV1Beta1: proposals},
+			StubVoted:     map[string]bool{valAccount: true, "cosmos456": true},
+		}
+		var metrics mockGovMetrics
+
+		task := NewGovTask(&metrics, client, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, proposals, metrics.GotProposals)
+
+		// Remembers the version.
+		err = task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, []GovVersion{GovV1, GovV1Beta1, GovV1Beta1}, client.GotVersions)
+	})
+
+	t.Run("vote error", func(t *testing.T) {
+		client := &mockGovClient{
+			StubProposals: map[GovVersion][]Proposal{GovV1: proposals},
+			StubVoted:     map[string]bool{valAccount: true},
+		}
+		var metrics mockGovMetrics
+
+		task := NewGovTask(&metrics, client, chain)
+		err := task.Run(ctx)
+
+		require.EqualError(t, err, "proposal 1 voter cosmos456: boom")
+		require.Len(t, metrics.GotVotes[1], 1)
+	})
+
+	t.Run("proposals error", func(t *testing.T) {
+		client := &mockGovClient{}
+		var metrics mockGovMetrics
+
+		task := NewGovTask(&metrics, client, chain)
+		err := task.Run(ctx)
+
+		require.Error(t, err)
+		require.Empty(t, metrics.GotChain)
+	})
+}
@@ -0,0 +1,182 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// LatestBlock queries the latest block from the gRPC API.
+func (c GRPCClient) LatestBlock(ctx context.Context) (Block, error) {
+	resp, err := c.client.Invoke(ctx, "/cosmos.base.tendermint.v1beta1.Service/GetLatestBlock", nil)
+	if err != nil {
+		return Block{}, err
+	}
+	return decodeBlockResponse(resp)
+}
+
+// BlockByHeight queries the block at height from the gRPC API.
+// Nodes prune old blocks, so requesting a height far behind the tip likely returns an error.
+func (c GRPCClient) BlockByHeight(ctx context.Context, height int64) (Block, error) {
+	req := protowire.AppendTag(nil, 1, protowire.VarintType)
+	req = protowire.AppendVarint(req, uint64(height))
+	resp, err := c.client.Invoke(ctx, "/cosmos.base.tendermint.v1beta1.Service/GetBlockByHeight", req)
+	if err != nil {
+		return Block{}, err
+	}
+	return decodeBlockResponse(resp)
+}
+
+// decodeBlockResponse decodes a cosmos.base.tendermint.v1beta1.GetLatestBlockResponse or GetBlockByHeightResponse.
+// Both have the same fields. Bytes are base64 encoded to match the REST API.
+func decodeBlockResponse(b []byte) (Block, error) {
+	var block Block
+	fields, err := protoFields(b)
+	if err != nil {
+		return block, err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			hash, err := decodeBlockIDHash(f.bytes)
+			if err != nil {
+				return block, err
+			}
+			block.BlockID.Hash = hash
+		case 2:
+			// The CometBFT block. Preferred over sdk_block (field 3) because it is available on all SDK versions.
+			if err = decodeBlock(&block, f.bytes); err != nil {
+				return block, err
+			}
+		}
+	}
+	return block, nil
+}
+
+// decodeBlock decodes a cometbft.types.v1.Block.
+func decodeBlock(block *Block, b []byte) error {
+	fields, err := protoFields(b)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			if err = decodeHeader(block, f.bytes); err != nil {
+				return err
+			}
+		case 2:
+			data, err := protoFields(f.bytes)
+			if err != nil {
+				return err
+			}
+			for _, tx := range data {
+				if tx.num == 1 {
+					block.Block.Data.Txs = append(block.Block.Data.Txs, base64.StdEncoding.EncodeToString(tx.bytes))
+				}
+			}
+		case 4:
+			if err = decodeCommit(block, f.bytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeHeader decodes a cometbft.types.v1.Header. Only the fields used by tasks are decoded.
+func decodeHeader(block *Block, b []byte) error {
+	fields, err := protoFields(b)
+	if err != nil {
+		return err
+	}
+	header := &block.Block.Header
+	for _, f := range fields {
+		switch f.num {
+		case 2:
+			header.ChainID = string(f.bytes)
+		case 3:
+			header.Height = strconv.FormatInt(int64(f.varint), 10)
+		case 4:
+			if header.Time, err = protoTime(f.bytes); err != nil {
+				return err
+			}
+		case 5:
+			if header.LastBlockID.Hash, err = decodeBlockIDHash(f.bytes); err != nil {
+				return err
+			}
+		case 14:
+			header.ProposerAddress = base64.StdEncoding.EncodeToString(f.bytes)
+		}
+	}
+	return nil
+}
+
+// decodeCommit decodes a cometbft.types.v1.Commit.
+func decodeCommit(block *Block, b []byte) error {
+	fields, err := protoFields(b)
+	if err != nil {
+		return err
+	}
+	commit := &block.Block.LastCommit
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			commit.Height = strconv.FormatInt(int64(f.varint), 10)
+		case 2:
+			commit.Round = int(int32(f.varint))
+		case 3:
+			if commit.BlockID.Hash, err = decodeBlockIDHash(f.bytes); err != nil {
+				return err
+			}
+		case 4:
+			sig, err := decodeCommitSig(f.bytes)
+			if err != nil {
+				return err
+			}
+			commit.Signatures = append(commit.Signatures, sig)
+		}
+	}
+	return nil
+}
+
+// decodeCommitSig decodes a cometbft.types.v1.CommitSig.
+func decodeCommitSig(b []byte) (CommitSig, error) {
+	var sig CommitSig
+	fields, err := protoFields(b)
+	if err != nil {
+		return sig, err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			sig.BlockIDFlag = blockIDFlagFromInt(int(f.varint))
+		case 2:
+			sig.ValidatorAddress = base64.StdEncoding.EncodeToString(f.bytes)
+		case 3:
+			if sig.Timestamp, err = protoTime(f.bytes); err != nil {
+				return sig, err
+			}
+		case 4:
+			sig.Signature = base64.StdEncoding.EncodeToString(f.bytes)
+		}
+	}
+	return sig, nil
+}
+
+// decodeBlockIDHash returns the base64 encoded hash of a cometbft.types.v1.BlockID.
+func decodeBlockIDHash(b []byte) (string, error) {
+	fields, err := protoFields(b)
+	if err != nil {
+		return "", err
+	}
+	var hash string
+	for _, f := range fields {
+		if f.num == 1 {
+			hash = base64.StdEncoding.EncodeToString(f.bytes)
+		}
+	}
+	return hash, nil
+}
@@ -0,0 +1,91 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func protoBlockResponse(height int64, signer []byte) []byte {
+	blockTime := time.Date(2023, 5, 12, 18, 31, 41, 0, time.UTC)
+
+	header := protoAppendString(nil, 2, "cosmoshub-4")
+	header = protoAppendVarint(header, 3, uint64(height))
+	header = protoAppendBytes(header, 4, protoTimestamp(blockTime))
+	header = protoAppendBytes(header, 14, signer)
+
+	signed := protoAppendVarint(nil, 1, 2)
+	signed = protoAppendBytes(signed, 2, signer)
+	signed = protoAppendBytes(signed, 3, protoTimestamp(blockTime))
+	signed = protoAppendBytes(signed, 4, []byte("sig"))
+	absent := protoAppendVarint(nil, 1, 1)
+
+	commit := protoAppendVarint(nil, 1, uint64(height-1))
+	commit = protoAppendVarint(commit, 2, 1)
+	commit = protoAppendBytes(commit, 3, protoAppendBytes(nil, 1, []byte("lasthash")))
+	commit = protoAppendBytes(commit, 4, signed)
+	commit = protoAppendBytes(commit, 4, absent)
+
+	block := protoAppendBytes(nil, 1, header)
+	block = protoAppendBytes(block, 2, protoAppendBytes(nil, 1, []byte("tx")))
+	block = protoAppendBytes(block, 4, commit)
+
+	resp := protoAppendBytes(nil, 1, protoAppendBytes(nil, 1, []byte("hash")))
+	return protoAppendBytes(resp, 2, block)
+}
+
+func TestGRPCClient_LatestBlock(t *testing.T) {
+	t.Parallel()
+
+	signer, err := base64.StdEncoding.DecodeString("1o7sDS6CSPHsZM21he22HspDK9g=")
+	require.NoError(t, err)
+
+	invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+		require.Equal(t, "/cosmos.base.tendermint.v1beta1.Service/GetLatestBlock", method)
+		require.Empty(t, req)
+		return protoBlockResponse(15312655, signer), nil
+	}}
+	client := NewGRPCClient(invoker)
+	got, err := client.LatestBlock(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("hash")), got.BlockID.Hash)
+	require.Equal(t, "cosmoshub-4", got.Block.Header.ChainID)
+	require.Equal(t, "15312655", got.Block.Header.Height)
+	require.Equal(t, time.Date(2023, 5, 12, 18, 31, 41, 0, time.UTC), got.Block.Header.Time)
+	require.Equal(t, "1o7sDS6CSPHsZM21he22HspDK9g=", got.Block.Header.ProposerAddress)
+	require.Equal(t, []string{base64.StdEncoding.EncodeToString([]byte("tx"))}, got.Block.Data.Txs)
+
+	commit := got.Block.LastCommit
+	require.Equal(t, "15312654", commit.Height)
+	require.Equal(t, 1, commit.Round)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("lasthash")), commit.BlockID.Hash)
+	require.Len(t, commit.Signatures, 2)
+	require.Equal(t, BlockIDFlagCommit, commit.Signatures[0].BlockIDFlag)
+	require.Equal(t, "1o7sDS6CSPHsZM21he22HspDK9g=", commit.Signatures[0].ValidatorAddress)
+	require.Equal(t, BlockIDFlagAbsent, commit.Signatures[1].BlockIDFlag)
+	require.Empty(t, commit.Signatures[1].ValidatorAddress)
+}
+
+func TestGRPCClient_BlockByHeight(t *testing.T) {
+	t.Parallel()
+
+	invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+		require.Equal(t, "/cosmos.base.tendermint.v1beta1.Service/GetBlockByHeight", method)
+		want := protowire.AppendTag(nil, 1, protowire.VarintType)
+		want = protowire.AppendVarint(want, 100)
+		require.Equal(t, want, req)
+		return protoBlockResponse(100, nil), nil
+	}}
+	client := NewGRPCClient(invoker)
+	got, err := client.BlockByHeight(context.Background(), 100)
+
+	require.NoError(t, err)
+	height, err := got.Height()
+	require.NoError(t, err)
+	require.EqualValues(t, 100, height)
+}
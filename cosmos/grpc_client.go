@@ -0,0 +1,21 @@
+package cosmos
+
+import "context"
+
+// GRPCClient is a client for the Cosmos gRPC API, typically on port 9090.
+// To find a list of methods, see the Query services in the Cosmos SDK proto files.
+type GRPCClient struct {
+	protoClient
+	client GRPCInvoker
+}
+
+type GRPCInvoker interface {
+	Invoke(ctx context.Context, method string, req []byte) ([]byte, error)
+}
+
+func NewGRPCClient(c GRPCInvoker) *GRPCClient {
+	return &GRPCClient{
+		protoClient: protoClient{query: c.Invoke},
+		client:      c,
+	}
+}
@@ -0,0 +1,115 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockGRPCInvoker struct {
+	InvokeFn func(ctx context.Context, method string, req []byte) ([]byte, error)
+}
+
+func (m mockGRPCInvoker) Invoke(ctx context.Context, method string, req []byte) ([]byte, error) {
+	return m.InvokeFn(ctx, method, req)
+}
+
+func TestGRPCClient_AccountBalance(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const account = "cosmos1xyerxdp4xcmnswfsxyerxdp4xcmnswfs2mnmda"
+
+	t.Run("happy path", func(t *testing.T) {
+		invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+			require.Equal(t, "/cosmos.bank.v1beta1.Query/Balance", method)
+			require.Equal(t, protoAppendString(protoAppendString(nil, 1, account), 2, "uatom"), req)
+
+			coin := protoAppendString(nil, 1, "uatom")
+			coin = protoAppendString(coin, 2, "123456")
+			return protoAppendBytes(nil, 1, coin), nil
+		}}
+		client := NewGRPCClient(invoker)
+		got, err := client.AccountBalance(ctx, account, "uatom")
+
+		require.NoError(t, err)
+		require.Equal(t, AccountBalance{Account: account, Denom: "uatom", Amount: 123456}, got)
+	})
+
+	t.Run("zero balance", func(t *testing.T) {
+		invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+			return protoAppendBytes(nil, 1, protoAppendString(nil, 1, "uatom")), nil
+		}}
+		client := NewGRPCClient(invoker)
+		got, err := client.AccountBalance(ctx, account, "uatom")
+
+		require.NoError(t, err)
+		require.Zero(t, got.Amount)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		}}
+		client := NewGRPCClient(invoker)
+		_, err := client.AccountBalance(ctx, account, "uatom")
+
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestGRPCClient_AccountBalances(t *testing.T) {
+	t.Parallel()
+
+	const account = "cosmos1xyerxdp4xcmnswfsxyerxdp4xcmnswfs2mnmda"
+	protoCoin := func(denom, amount string) []byte {
+		return protoAppendString(protoAppendString(nil, 1, denom), 2, amount)
+	}
+
+	var calls int
+	invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+		require.Equal(t, "/cosmos.bank.v1beta1.Query/AllBalances", method)
+		calls++
+
+		var resp []byte
+		switch calls {
+		case 1:
+			require.Equal(t, encodeAllBalancesRequest(account, nil), req)
+			resp = protoAppendBytes(resp, 1, protoCoin("ibc/ABC", "12"))
+			resp = protoAppendBytes(resp, 2, protoAppendBytes(nil, 1, []byte("next")))
+		case 2:
+			require.Equal(t, encodeAllBalancesRequest(account, []byte("next")), req)
+			resp = protoAppendBytes(resp, 1, protoCoin("uatom", "123456"))
+		default:
+			t.Fatalf("unexpected call %d", calls)
+		}
+		return resp, nil
+	}}
+	client := NewGRPCClient(invoker)
+	got, err := client.AccountBalances(context.Background(), account)
+
+	require.NoError(t, err)
+	require.Equal(t, []AccountBalance{
+		{Account: account, Denom: "ibc/ABC", Amount: 12},
+		{Account: account, Denom: "uatom", Amount: 123456},
+	}, got)
+}
+
+func TestGRPCClient_SigningInfo(t *testing.T) {
+	t.Parallel()
+
+	const consaddress = "cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g"
+	invoker := mockGRPCInvoker{InvokeFn: func(ctx context.Context, method string, req []byte) ([]byte, error) {
+		require.Equal(t, "/cosmos.slashing.v1beta1.Query/SigningInfo", method)
+		info := protoAppendString(nil, 1, consaddress)
+		info = protoAppendVarint(info, 6, 7)
+		return protoAppendBytes(nil, 1, info), nil
+	}}
+	client := NewGRPCClient(invoker)
+	got, err := client.SigningInfo(context.Background(), consaddress)
+
+	require.NoError(t, err)
+	require.Equal(t, "7", got.ValSigningInfo.MissedBlocksCounter)
+}
@@ -0,0 +1,449 @@
+package cosmos
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Minimal protobuf encoding of Cosmos SDK query messages for transports that do not speak JSON.
+// Hand decoding the few messages we need avoids depending on the SDK's generated types.
+// Field numbers are from the SDK's proto files. Unknown fields are ignored.
+
+type protoField struct {
+	num    protowire.Number
+	varint uint64
+	bytes  []byte
+}
+
+// protoFields returns the top-level fields of a protobuf message in wire order.
+func protoFields(b []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		f := protoField{num: num}
+		switch typ {
+		case protowire.VarintType:
+			f.varint, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// protoEmbedded returns the fields of the last embedded message with the field number.
+func protoEmbedded(b []byte, num protowire.Number) ([]protoField, error) {
+	fields, err := protoFields(b)
+	if err != nil {
+		return nil, err
+	}
+	var msg []byte
+	for _, f := range fields {
+		if f.num == num {
+			msg = f.bytes
+		}
+	}
+	return protoFields(msg)
+}
+
+func protoAppendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func protoAppendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// protoSecondsNanos decodes the fields shared by google.protobuf.Timestamp and google.protobuf.Duration.
+func protoSecondsNanos(b []byte) (sec, nanos int64, err error) {
+	fields, err := protoFields(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			sec = int64(f.varint)
+		case 2:
+			nanos = int64(int32(f.varint))
+		}
+	}
+	return sec, nanos, nil
+}
+
+// protoTime decodes a google.protobuf.Timestamp.
+func protoTime(b []byte) (time.Time, error) {
+	sec, nanos, err := protoSecondsNanos(b)
+	return time.Unix(sec, nanos).UTC(), err
+}
+
+// protoDuration decodes a google.protobuf.Duration.
+func protoDuration(b []byte) (time.Duration, error) {
+	sec, nanos, err := protoSecondsNanos(b)
+	return time.Duration(sec)*time.Second + time.Duration(nanos), err
+}
+
+// durationString formats a duration like the JSON form of google.protobuf.Duration, e.g. 600s or 1.500s.
+func durationString(d time.Duration) string {
+	sec, nanos := d/time.Second, d%time.Second
+	if nanos == 0 {
+		return fmt.Sprintf("%ds", sec)
+	}
+	frac := fmt.Sprintf("%09d", nanos)
+	// The JSON form has 3, 6 or 9 fractional digits.
+	for strings.HasSuffix(frac, "000") {
+		frac = strings.TrimSuffix(frac, "000")
+	}
+	return fmt.Sprintf("%d.%ss", sec, frac)
+}
+
+// legacyDecString converts a protobuf encoded math.LegacyDec to its JSON form, e.g. 50000000000000000
+// becomes 0.050000000000000000. The protobuf form is an integer scaled by 10^18.
+func legacyDecString(s string) string {
+	const precision = 18
+	if s == "" {
+		return ""
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) <= precision {
+		s = strings.Repeat("0", precision+1-len(s)) + s
+	}
+	s = s[:len(s)-precision] + "." + s[len(s)-precision:]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// decodeSigningInfoResponse decodes a cosmos.slashing.v1beta1.QuerySigningInfoResponse.
+func decodeSigningInfoResponse(b []byte) (SigningInfo, error) {
+	var info SigningInfo
+	fields, err := protoEmbedded(b, 1)
+	if err != nil {
+		return info, err
+	}
+	v := &info.ValSigningInfo
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			v.Address = string(f.bytes)
+		case 2:
+			v.StartHeight = strconv.FormatInt(int64(f.varint), 10)
+		case 3:
+			v.IndexOffset = strconv.FormatInt(int64(f.varint), 10)
+		case 4:
+			if v.JailedUntil, err = protoTime(f.bytes); err != nil {
+				return info, err
+			}
+		case 5:
+			v.Tombstoned = f.varint != 0
+		case 6:
+			v.MissedBlocksCounter = strconv.FormatInt(int64(f.varint), 10)
+		}
+	}
+	if v.Address == "" {
+		return info, errors.New("missing signing info")
+	}
+	return info, nil
+}
+
+// decodeSlashingParamsResponse decodes a cosmos.slashing.v1beta1.QueryParamsResponse.
+func decodeSlashingParamsResponse(b []byte) (SlashingParams, error) {
+	var params SlashingParams
+	fields, err := protoEmbedded(b, 1)
+	if err != nil {
+		return params, err
+	}
+	p := &params.Params
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			p.SignedBlocksWindow = strconv.FormatInt(int64(f.varint), 10)
+		case 2:
+			p.MinSignedPerWindow = legacyDecString(string(f.bytes))
+		case 3:
+			d, err := protoDuration(f.bytes)
+			if err != nil {
+				return params, err
+			}
+			p.DowntimeJailDuration = durationString(d)
+		case 4:
+			p.SlashFractionDoubleSign = legacyDecString(string(f.bytes))
+		case 5:
+			p.SlashFractionDowntime = legacyDecString(string(f.bytes))
+		}
+	}
+	return params, nil
+}
+
+type protoCoin struct {
+	Denom  string
+	Amount string
+}
+
+// decodeBalanceResponse decodes a cosmos.bank.v1beta1.QueryBalanceResponse for the requested denom.
+func decodeBalanceResponse(b []byte, denom string) (protoCoin, error) {
+	coin := protoCoin{Denom: denom}
+	fields, err := protoEmbedded(b, 1)
+	if err != nil {
+		return coin, err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			if len(f.bytes) > 0 {
+				coin.Denom = string(f.bytes)
+			}
+		case 2:
+			coin.Amount = string(f.bytes)
+		}
+	}
+	// A zero balance is encoded as an empty message. Match the REST API which returns the denom and "0".
+	if coin.Amount == "" {
+		coin.Amount = "0"
+	}
+	return coin, nil
+}
+
+// encodeAllBalancesRequest encodes a cosmos.bank.v1beta1.QueryAllBalancesRequest with a page size of 200.
+func encodeAllBalancesRequest(account string, pageKey []byte) []byte {
+	req := protoAppendString(nil, 1, account)
+	return protoAppendBytes(req, 2, encodePageRequest(pageKey))
+}
+
+// decodeAllBalancesResponse decodes a cosmos.bank.v1beta1.QueryAllBalancesResponse.
+// Returns the coins and the key of the next page, which is empty on the last page.
+func decodeAllBalancesResponse(b []byte) ([]protoCoin, []byte, error) {
+	fields, err := protoFields(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		coins   []protoCoin
+		nextKey []byte
+	)
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			coinFields, err := protoFields(f.bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			var coin protoCoin
+			for _, cf := range coinFields {
+				switch cf.num {
+				case 1:
+					coin.Denom = string(cf.bytes)
+				case 2:
+					coin.Amount = string(cf.bytes)
+				}
+			}
+			coins = append(coins, coin)
+		case 2:
+			if nextKey, err = decodePageResponse(f.bytes); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return coins, nextKey, nil
+}
+
+var bondStatusNames = map[uint64]string{
+	0: "BOND_STATUS_UNSPECIFIED",
+	1: "BOND_STATUS_UNBONDED",
+	2: "BOND_STATUS_UNBONDING",
+	3: "BOND_STATUS_BONDED",
+}
+
+// decodeValidatorResponse decodes a cosmos.staking.v1beta1.QueryValidatorResponse.
+func decodeValidatorResponse(b []byte) (StakingValidator, error) {
+	fields, err := protoEmbedded(b, 1)
+	if err != nil {
+		return StakingValidator{}, err
+	}
+	return decodeValidator(fields)
+}
+
+// encodePageRequest encodes a cosmos.base.query.v1beta1.PageRequest with a page size of 200.
+func encodePageRequest(pageKey []byte) []byte {
+	var page []byte
+	if len(pageKey) > 0 {
+		page = protoAppendBytes(page, 1, pageKey)
+	}
+	page = protowire.AppendTag(page, 3, protowire.VarintType)
+	return protowire.AppendVarint(page, 200)
+}
+
+// decodePageResponse returns the next key of a cosmos.base.query.v1beta1.PageResponse.
+func decodePageResponse(b []byte) ([]byte, error) {
+	fields, err := protoFields(b)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if f.num == 1 {
+			return f.bytes, nil
+		}
+	}
+	return nil, nil
+}
+
+// encodeValidatorsRequest encodes a cosmos.staking.v1beta1.QueryValidatorsRequest with a page size of 200.
+func encodeValidatorsRequest(status string, pageKey []byte) []byte {
+	req := protoAppendString(nil, 1, status)
+	return protoAppendBytes(req, 2, encodePageRequest(pageKey))
+}
+
+// decodeValidatorsResponse decodes a cosmos.staking.v1beta1.QueryValidatorsResponse.
+// Returns the validators and the key of the next page, which is empty on the last page.
+func decodeValidatorsResponse(b []byte) ([]StakingValidator, []byte, error) {
+	fields, err := protoFields(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		vals    []StakingValidator
+		nextKey []byte
+	)
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			valFields, err := protoFields(f.bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			val, err := decodeValidator(valFields)
+			if err != nil {
+				return nil, nil, err
+			}
+			vals = append(vals, val)
+		case 2:
+			if nextKey, err = decodePageResponse(f.bytes); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return vals, nextKey, nil
+}
+
+// decodeValidator decodes the fields of a cosmos.staking.v1beta1.Validator.
+func decodeValidator(fields []protoField) (StakingValidator, error) {
+	var (
+		val StakingValidator
+		err error
+	)
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			val.OperatorAddress = string(f.bytes)
+		case 2:
+			if err = decodePubKey(&val, f.bytes); err != nil {
+				return val, err
+			}
+		case 3:
+			val.Jailed = f.varint != 0
+		case 4:
+			val.Status = bondStatusNames[f.varint]
+		case 5:
+			val.Tokens = string(f.bytes)
+		case 6:
+			val.DelegatorShares = legacyDecString(string(f.bytes))
+		case 7:
+			desc, err := protoFields(f.bytes)
+			if err != nil {
+				return val, err
+			}
+			for _, d := range desc {
+				if d.num == 1 {
+					val.Description.Moniker = string(d.bytes)
+				}
+			}
+		case 10:
+			if err = decodeCommission(&val, f.bytes); err != nil {
+				return val, err
+			}
+		}
+	}
+	if val.OperatorAddress == "" {
+		return val, errors.New("missing validator")
+	}
+	return val, nil
+}
+
+// decodePubKey decodes a google.protobuf.Any containing a public key such as cosmos.crypto.ed25519.PubKey.
+func decodePubKey(val *StakingValidator, b []byte) error {
+	fields, err := protoFields(b)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			val.ConsensusPubkey.Type = string(f.bytes)
+		case 2:
+			key, err := protoFields(f.bytes)
+			if err != nil {
+				return err
+			}
+			for _, k := range key {
+				if k.num == 1 {
+					val.ConsensusPubkey.Key = base64.StdEncoding.EncodeToString(k.bytes)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// decodeCommission decodes a cosmos.staking.v1beta1.Commission.
+func decodeCommission(val *StakingValidator, b []byte) error {
+	fields, err := protoFields(b)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			rates, err := protoFields(f.bytes)
+			if err != nil {
+				return err
+			}
+			r := &val.Commission.CommissionRates
+			for _, rate := range rates {
+				switch rate.num {
+				case 1:
+					r.Rate = legacyDecString(string(rate.bytes))
+				case 2:
+					r.MaxRate = legacyDecString(string(rate.bytes))
+				case 3:
+					r.MaxChangeRate = legacyDecString(string(rate.bytes))
+				}
+			}
+		case 2:
+			if val.Commission.UpdateTime, err = protoTime(f.bytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
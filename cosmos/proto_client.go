@@ -0,0 +1,121 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// protoClient queries module state for transports that exchange protobuf encoded messages, i.e. the CometBFT RPC
+// via abci_query and gRPC. Both address queries by the gRPC method, e.g. /cosmos.slashing.v1beta1.Query/Params.
+type protoClient struct {
+	query func(ctx context.Context, method string, req []byte) ([]byte, error)
+}
+
+// SigningInfo returns the signing status of a validator given the consensus address.
+func (c protoClient) SigningInfo(ctx context.Context, consaddress string) (SigningInfo, error) {
+	req := protoAppendString(nil, 1, consaddress)
+	resp, err := c.query(ctx, "/cosmos.slashing.v1beta1.Query/SigningInfo", req)
+	if err != nil {
+		return SigningInfo{}, err
+	}
+	return decodeSigningInfoResponse(resp)
+}
+
+// SlashingParams returns the params of the slashing module.
+func (c protoClient) SlashingParams(ctx context.Context) (SlashingParams, error) {
+	resp, err := c.query(ctx, "/cosmos.slashing.v1beta1.Query/Params", nil)
+	if err != nil {
+		return SlashingParams{}, err
+	}
+	return decodeSlashingParamsResponse(resp)
+}
+
+// StakingValidator returns the staking record of a validator given the operator address.
+func (c protoClient) StakingValidator(ctx context.Context, valoper string) (StakingValidator, error) {
+	req := protoAppendString(nil, 1, valoper)
+	resp, err := c.query(ctx, "/cosmos.staking.v1beta1.Query/Validator", req)
+	if err != nil {
+		return StakingValidator{}, err
+	}
+	return decodeValidatorResponse(resp)
+}
+
+// BondedValidators returns all validators in the active set in no particular order.
+func (c protoClient) BondedValidators(ctx context.Context) ([]StakingValidator, error) {
+	var (
+		vals    []StakingValidator
+		nextKey []byte
+	)
+	for {
+		req := encodeValidatorsRequest("BOND_STATUS_BONDED", nextKey)
+		resp, err := c.query(ctx, "/cosmos.staking.v1beta1.Query/Validators", req)
+		if err != nil {
+			return nil, err
+		}
+		var page []StakingValidator
+		page, nextKey, err = decodeValidatorsResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, page...)
+
+		if len(nextKey) == 0 {
+			return vals, nil
+		}
+	}
+}
+
+// AccountBalance returns the balance of an account for a denom.
+// If the denom is not found, returns a balance of 0 instead of an error.
+func (c protoClient) AccountBalance(ctx context.Context, account, denom string) (AccountBalance, error) {
+	req := protoAppendString(nil, 1, account)
+	req = protoAppendString(req, 2, denom)
+	resp, err := c.query(ctx, "/cosmos.bank.v1beta1.Query/Balance", req)
+	if err != nil {
+		return AccountBalance{}, err
+	}
+	coin, err := decodeBalanceResponse(resp, denom)
+	if err != nil {
+		return AccountBalance{}, err
+	}
+	amount, err := strconv.ParseFloat(coin.Amount, 64)
+	if err != nil {
+		return AccountBalance{}, fmt.Errorf("malformed amount: %w", err)
+	}
+	return AccountBalance{
+		Account: account,
+		Denom:   coin.Denom,
+		Amount:  amount,
+	}, nil
+}
+
+// AccountBalances returns all non-zero balances of an account.
+func (c protoClient) AccountBalances(ctx context.Context, account string) ([]AccountBalance, error) {
+	var (
+		balances []AccountBalance
+		nextKey  []byte
+	)
+	for {
+		resp, err := c.query(ctx, "/cosmos.bank.v1beta1.Query/AllBalances", encodeAllBalancesRequest(account, nextKey))
+		if err != nil {
+			return nil, err
+		}
+		var coins []protoCoin
+		coins, nextKey, err = decodeAllBalancesResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, coin := range coins {
+			amount, err := strconv.ParseFloat(coin.Amount, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed amount for %s: %w", coin.Denom, err)
+			}
+			balances = append(balances, AccountBalance{Account: account, Denom: coin.Denom, Amount: amount})
+		}
+
+		if len(nextKey) == 0 {
+			return balances, nil
+		}
+	}
+}
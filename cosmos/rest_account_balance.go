@@ -3,6 +3,7 @@ package cosmos
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/url"
 	"path"
 	"strconv"
@@ -12,6 +13,17 @@ type AccountBalance struct {
 	Account string
 	Denom   string
 	Amount  float64
+	// Metadata is set by account tasks, not clients.
+	Metadata DenomMetadata
+}
+
+// DisplayAmount returns the amount in display units, e.g. atom instead of uatom.
+// Returns false if the display denom is unknown.
+func (b AccountBalance) DisplayAmount() (float64, bool) {
+	if b.Metadata.Display == "" {
+		return 0, false
+	}
+	return b.Amount / math.Pow10(b.Metadata.Exponent), true
 }
 
 // AccountBalance returns the balance of an account. The account is a bech32 address with prefix.
@@ -42,9 +54,56 @@ func (c RestClient) AccountBalance(ctx context.Context, account, denom string) (
 		return AccountBalance{}, fmt.Errorf("malformed amount: %w", err)
 	}
 
+	// The denom is a metric label, so never leave it empty.
+	if resp.Balance.Denom != "" {
+		denom = resp.Balance.Denom
+	}
 	return AccountBalance{
 		Account: account,
-		Denom:   resp.Balance.Denom,
+		Denom:   denom,
 		Amount:  amount,
 	}, nil
 }
+
+// AccountBalances returns all non-zero balances of an account.
+// Docs: https://docs.cosmos.network/swagger/#/Query/AllBalances
+func (c RestClient) AccountBalances(ctx context.Context, account string) ([]AccountBalance, error) {
+	var (
+		balances []AccountBalance
+		nextKey  string
+	)
+	for {
+		u := url.URL{Path: path.Join("/cosmos/bank/v1beta1/balances", account)}
+		q := u.Query()
+		q.Set("pagination.limit", "200")
+		if nextKey != "" {
+			q.Set("pagination.key", nextKey)
+		}
+		u.RawQuery = q.Encode()
+
+		var resp struct {
+			Balances []struct {
+				Denom  string `json:"denom"`
+				Amount string `json:"amount"`
+			} `json:"balances"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		if err := c.get(ctx, u, &resp); err != nil {
+			return nil, err
+		}
+		for _, coin := range resp.Balances {
+			amount, err := strconv.ParseFloat(coin.Amount, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed amount for %s: %w", coin.Denom, err)
+			}
+			balances = append(balances, AccountBalance{Account: account, Denom: coin.Denom, Amount: amount})
+		}
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return balances, nil
+		}
+	}
+}
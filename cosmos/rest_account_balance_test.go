@@ -64,3 +64,56 @@ func TestAccountBalance(t *testing.T) {
 		require.EqualError(t, err, "boom")
 	})
 }
+
+func TestAccountBalances(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var calls int
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.Equal(t, "/cosmos/bank/v1beta1/balances/cosmos123", path.Path)
+			calls++
+
+			var response string
+			switch calls {
+			case 1:
+				require.Equal(t, "pagination.limit=200", path.RawQuery)
+				response = `{"balances":[{"denom":"ibc/ABC","amount":"12"}],"pagination":{"next_key":"bmV4dA=="}}`
+			case 2:
+				require.Equal(t, "bmV4dA==", path.Query().Get("pagination.key"))
+				response = `{"balances":[{"denom":"uatom","amount":"1107254710"}],"pagination":{"next_key":null}}`
+			default:
+				t.Fatalf("unexpected call %d", calls)
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(response)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.AccountBalances(ctx, "cosmos123")
+
+		require.NoError(t, err)
+		require.Equal(t, []AccountBalance{
+			{Account: "cosmos123", Denom: "ibc/ABC", Amount: 12},
+			{Account: "cosmos123", Denom: "uatom", Amount: 1107254710},
+		}, got)
+	})
+
+	t.Run("malformed amount", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, _ url.URL) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"balances":[{"denom":"uatom","amount":"bad"}]}`)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		_, err := client.AccountBalances(ctx, "cosmos123")
+
+		require.ErrorContains(t, err, "malformed amount for uatom")
+	})
+}
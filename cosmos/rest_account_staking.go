@@ -0,0 +1,258 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// AccountStaking is the staking position of an account.
+type AccountStaking struct {
+	// BondDenom is the denom of delegations, unbondings and redelegations, e.g. uatom.
+	BondDenom     string
+	Delegations   []Delegation
+	Unbondings    []UnbondingEntry
+	Redelegations []RedelegationEntry
+	Rewards       []DelegationReward
+}
+
+// Delegation is the amount an account delegated to a validator.
+type Delegation struct {
+	Validator string
+	Denom     string
+	Amount    float64
+}
+
+// UnbondingEntry is an amount an account is unbonding from a validator.
+type UnbondingEntry struct {
+	Validator      string
+	CreationHeight int64
+	CompletionTime time.Time
+	Amount         float64
+}
+
+// RedelegationEntry is an amount an account is redelegating between validators.
+// Until the completion time, the amount is slashed if the source validator misbehaved before the redelegation.
+type RedelegationEntry struct {
+	SrcValidator   string
+	DstValidator   string
+	CreationHeight int64
+	CompletionTime time.Time
+	Amount         float64
+}
+
+// DelegationReward is the claimable staking reward of an account from a validator for a denom.
+type DelegationReward struct {
+	Validator string
+	Denom     string
+	Amount    float64
+}
+
+// BondDenom returns the denom used for staking, e.g. uatom.
+// Docs: https://docs.cosmos.network/swagger/#/Query/StakingParams
+func (c RestClient) BondDenom(ctx context.Context) (string, error) {
+	var resp struct {
+		Params struct {
+			BondDenom string `json:"bond_denom"`
+		} `json:"params"`
+	}
+	err := c.get(ctx, url.URL{Path: "/cosmos/staking/v1beta1/params"}, &resp)
+	return resp.Params.BondDenom, err
+}
+
+// Delegations returns the delegations of an account.
+// Docs: https://docs.cosmos.network/swagger/#/Query/DelegatorDelegations
+func (c RestClient) Delegations(ctx context.Context, delegator string) ([]Delegation, error) {
+	var (
+		delegations []Delegation
+		nextKey     string
+	)
+	for {
+		var resp struct {
+			DelegationResponses []struct {
+				Delegation struct {
+					ValidatorAddress string `json:"validator_address"`
+				} `json:"delegation"`
+				Balance struct {
+					Denom  string `json:"denom"`
+					Amount string `json:"amount"`
+				} `json:"balance"`
+			} `json:"delegation_responses"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		if err := c.get(ctx, pageURL(path.Join("/cosmos/staking/v1beta1/delegations", delegator), nextKey), &resp); err != nil {
+			return nil, err
+		}
+		for _, d := range resp.DelegationResponses {
+			amount, err := strconv.ParseFloat(d.Balance.Amount, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed amount: %w", err)
+			}
+			delegations = append(delegations, Delegation{
+				Validator: d.Delegation.ValidatorAddress,
+				Denom:     d.Balance.Denom,
+				Amount:    amount,
+			})
+		}
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return delegations, nil
+		}
+	}
+}
+
+// UnbondingDelegations returns the unbonding entries of an account.
+// Docs: https://docs.cosmos.network/swagger/#/Query/DelegatorUnbondingDelegations
+func (c RestClient) UnbondingDelegations(ctx context.Context, delegator string) ([]UnbondingEntry, error) {
+	var (
+		entries []UnbondingEntry
+		nextKey string
+	)
+	for {
+		var resp struct {
+			UnbondingResponses []struct {
+				ValidatorAddress string `json:"validator_address"`
+				Entries          []struct {
+					CreationHeight string    `json:"creation_height"`
+					CompletionTime time.Time `json:"completion_time"`
+					Balance        string    `json:"balance"`
+				} `json:"entries"`
+			} `json:"unbonding_responses"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		p := path.Join("/cosmos/staking/v1beta1/delegators", delegator, "unbonding_delegations")
+		if err := c.get(ctx, pageURL(p, nextKey), &resp); err != nil {
+			return nil, err
+		}
+		for _, ubd := range resp.UnbondingResponses {
+			for _, e := range ubd.Entries {
+				height, err := strconv.ParseInt(e.CreationHeight, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("malformed creation height: %w", err)
+				}
+				amount, err := strconv.ParseFloat(e.Balance, 64)
+				if err != nil {
+					return nil, fmt.Errorf("malformed balance: %w", err)
+				}
+				entries = append(entries, UnbondingEntry{
+					Validator:      ubd.ValidatorAddress,
+					CreationHeight: height,
+					CompletionTime: e.CompletionTime,
+					Amount:         amount,
+				})
+			}
+		}
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return entries, nil
+		}
+	}
+}
+
+// Redelegations returns the redelegation entries of an account.
+// Docs: https://docs.cosmos.network/swagger/#/Query/Redelegations
+func (c RestClient) Redelegations(ctx context.Context, delegator string) ([]RedelegationEntry, error) {
+	var (
+		entries []RedelegationEntry
+		nextKey string
+	)
+	for {
+		var resp struct {
+			RedelegationResponses []struct {
+				Redelegation struct {
+					ValidatorSrcAddress string `json:"validator_src_address"`
+					ValidatorDstAddress string `json:"validator_dst_address"`
+				} `json:"redelegation"`
+				Entries []struct {
+					RedelegationEntry struct {
+						CreationHeight string    `json:"creation_height"`
+						CompletionTime time.Time `json:"completion_time"`
+					} `json:"redelegation_entry"`
+					Balance string `json:"balance"`
+				} `json:"entries"`
+			} `json:"redelegation_responses"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		p := path.Join("/cosmos/staking/v1beta1/delegators", delegator, "redelegations")
+		if err := c.get(ctx, pageURL(p, nextKey), &resp); err != nil {
+			return nil, err
+		}
+		for _, red := range resp.RedelegationResponses {
+			for _, e := range red.Entries {
+				height, err := strconv.ParseInt(e.RedelegationEntry.CreationHeight, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("malformed creation height: %w", err)
+				}
+				amount, err := strconv.ParseFloat(e.Balance, 64)
+				if err != nil {
+					return nil, fmt.Errorf("malformed balance: %w", err)
+				}
+				entries = append(entries, RedelegationEntry{
+					SrcValidator:   red.Redelegation.ValidatorSrcAddress,
+					DstValidator:   red.Redelegation.ValidatorDstAddress,
+					CreationHeight: height,
+					CompletionTime: e.RedelegationEntry.CompletionTime,
+					Amount:         amount,
+				})
+			}
+		}
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return entries, nil
+		}
+	}
+}
+
+// DelegationRewards returns the claimable staking rewards of an account per validator.
+// Docs: https://docs.cosmos.network/swagger/#/Query/DelegationTotalRewards
+func (c RestClient) DelegationRewards(ctx context.Context, delegator string) ([]DelegationReward, error) {
+	var resp struct {
+		Rewards []struct {
+			ValidatorAddress string `json:"validator_address"`
+			Reward           []struct {
+				Denom  string `json:"denom"`
+				Amount string `json:"amount"`
+			} `json:"reward"`
+		} `json:"rewards"`
+	}
+	p := path.Join("/cosmos/distribution/v1beta1/delegators", delegator, "rewards")
+	if err := c.get(ctx, url.URL{Path: p}, &resp); err != nil {
+		return nil, err
+	}
+	var rewards []DelegationReward
+	for _, r := range resp.Rewards {
+		for _, coin := range r.Reward {
+			// Rewards are decimals, e.g. 123.450000000000000000.
+			amount, err := strconv.ParseFloat(coin.Amount, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed amount: %w", err)
+			}
+			rewards = append(rewards, DelegationReward{Validator: r.ValidatorAddress, Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return rewards, nil
+}
+
+// pageURL returns a url for the path with a page size of 200 starting at the page key, if any.
+func pageURL(p, pageKey string) url.URL {
+	u := url.URL{Path: p}
+	q := u.Query()
+	q.Set("pagination.limit", "200")
+	if pageKey != "" {
+		q.Set("pagination.key", pageKey)
+	}
+	u.RawQuery = q.Encode()
+	return u
+}
@@ -0,0 +1,97 @@
+package cosmos
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stubJSONClient(t *testing.T, wantPath string, responses ...string) mockHTTPClient {
+	t.Helper()
+	var calls int
+	return mockHTTPClient{GetFn: func(ctx context.Context, path url.URL) (*http.Response, error) {
+		require.Equal(t, wantPath, path.Path)
+		require.Less(t, calls, len(responses), "unexpected call")
+		resp := responses[calls]
+		calls++
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(resp))}, nil
+	}}
+}
+
+func TestRestClient_BondDenom(t *testing.T) {
+	t.Parallel()
+
+	httpClient := stubJSONClient(t, "/cosmos/staking/v1beta1/params", `{"params":{"unbonding_time":"1814400s","bond_denom":"uatom"}}`)
+	got, err := NewRestClient(httpClient).BondDenom(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, "uatom", got)
+}
+
+func TestRestClient_Delegations(t *testing.T) {
+	t.Parallel()
+
+	httpClient := stubJSONClient(t, "/cosmos/staking/v1beta1/delegations/cosmos123",
+		`{"delegation_responses":[{"delegation":{"delegator_address":"cosmos123","validator_address":"cosmosvaloper1","shares":"100.000000000000000000"},"balance":{"denom":"uatom","amount":"100"}}],"pagination":{"next_key":"bmV4dA=="}}`,
+		`{"delegation_responses":[{"delegation":{"delegator_address":"cosmos123","validator_address":"cosmosvaloper2","shares":"50.000000000000000000"},"balance":{"denom":"uatom","amount":"50"}}],"pagination":{"next_key":null}}`,
+	)
+	got, err := NewRestClient(httpClient).Delegations(context.Background(), "cosmos123")
+
+	require.NoError(t, err)
+	require.Equal(t, []Delegation{
+		{Validator: "cosmosvaloper1", Denom: "uatom", Amount: 100},
+		{Validator: "cosmosvaloper2", Denom: "uatom", Amount: 50},
+	}, got)
+}
+
+func TestRestClient_UnbondingDelegations(t *testing.T) {
+	t.Parallel()
+
+	httpClient := stubJSONClient(t, "/cosmos/staking/v1beta1/delegators/cosmos123/unbonding_delegations",
+		`{"unbonding_responses":[{"delegator_address":"cosmos123","validator_address":"cosmosvaloper1","entries":[{"creation_height":"123","completion_time":"2024-01-02T03:04:05Z","initial_balance":"10","balance":"9"}]}],"pagination":{"next_key":null}}`,
+	)
+	got, err := NewRestClient(httpClient).UnbondingDelegations(context.Background(), "cosmos123")
+
+	require.NoError(t, err)
+	require.Equal(t, []UnbondingEntry{
+		{Validator: "cosmosvaloper1", CreationHeight: 123, CompletionTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Amount: 9},
+	}, got)
+}
+
+func TestRestClient_Redelegations(t *testing.T) {
+	t.Parallel()
+
+	httpClient := stubJSONClient(t, "/cosmos/staking/v1beta1/delegators/cosmos123/redelegations",
+		`{"redelegation_responses":[{"redelegation":{"delegator_address":"cosmos123","validator_src_address":"cosmosvaloper1","validator_dst_address":"cosmosvaloper2","entries":[]},"entries":[{"redelegation_entry":{"creation_height":"123","completion_time":"2024-01-02T03:04:05Z","initial_balance":"10","shares_dst":"10.000000000000000000"},"balance":"10"}]}],"pagination":{"next_key":null}}`,
+	)
+	got, err := NewRestClient(httpClient).Redelegations(context.Background(), "cosmos123")
+
+	require.NoError(t, err)
+	require.Equal(t, []RedelegationEntry{
+		{
+			SrcValidator:   "cosmosvaloper1",
+			DstValidator:   "cosmosvaloper2",
+			CreationHeight: 123,
+			CompletionTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Amount:         10,
+		},
+	}, got)
+}
+
+func TestRestClient_DelegationRewards(t *testing.T) {
+	t.Parallel()
+
+	httpClient := stubJSONClient(t, "/cosmos/distribution/v1beta1/delegators/cosmos123/rewards",
+		`{"rewards":[{"validator_address":"cosmosvaloper1","reward":[{"denom":"uatom","amount":"123.450000000000000000"}]}],"total":[{"denom":"uatom","amount":"123.450000000000000000"}]}`,
+	)
+	got, err := NewRestClient(httpClient).DelegationRewards(context.Background(), "cosmos123")
+
+	require.NoError(t, err)
+	require.Equal(t, []DelegationReward{{Validator: "cosmosvaloper1", Denom: "uatom", Amount: 123.45}}, got)
+}
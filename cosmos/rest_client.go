@@ -3,9 +3,11 @@ package cosmos
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // RestClient is a client for the Cosmos REST API.
@@ -14,6 +16,22 @@ type RestClient struct {
 	client HTTPClient
 }
 
+// RestCacheTTLs are how long responses of slow-changing REST paths are cached by default, keyed by path template.
+// See Chain.CacheTTLs to override them.
+var RestCacheTTLs = map[string]time.Duration{
+	"/cosmos/slashing/v1beta1/params":      time.Hour,
+	"/cosmos/upgrade/v1beta1/current_plan": 5 * time.Minute,
+	"/cosmos/gov/v1/proposals":             time.Minute,
+	"/cosmos/gov/v1beta1/proposals":        time.Minute,
+	"/cosmos/bank/v1beta1/denoms_metadata": time.Hour,
+	"/cosmos/staking/v1beta1/params":       time.Hour,
+	// Validator records are fetched by several tasks. Tokens and jail status change, so the TTL is short.
+	"/cosmos/staking/v1beta1/validators/{address}": time.Minute,
+	// Denom traces never change.
+	"/ibc/apps/transfer/v1/denom_traces/{hash}": 24 * time.Hour,
+	"/ibc/apps/transfer/v1/denoms/{hash}":       24 * time.Hour,
+}
+
 type HTTPClient interface {
 	Get(ctx context.Context, path url.URL) (*http.Response, error)
 }
@@ -37,3 +55,33 @@ func (c RestClient) get(ctx context.Context, path url.URL, response any) error {
 	}
 	return nil
 }
+
+// statusCoder is implemented by errors for responses with an unsuccessful status code, e.g. metrics.StatusError.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// hasStatusCode returns true if err was caused by a response with one of the status codes.
+func hasStatusCode(err error, codes ...int) bool {
+	var sc statusCoder
+	if !errors.As(err, &sc) {
+		return false
+	}
+	for _, code := range codes {
+		if sc.StatusCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// notFounder is implemented by errors for responses saying the resource does not exist, e.g. metrics.StatusError.
+type notFounder interface {
+	NotFound() bool
+}
+
+// isNotFoundError returns true if err was caused by a response saying the resource does not exist.
+func isNotFoundError(err error) bool {
+	var nf notFounder
+	return errors.As(err, &nf) && nf.NotFound()
+}
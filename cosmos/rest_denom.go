@@ -0,0 +1,108 @@
+package cosmos
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// DenomMetadata describes the units of a denom.
+type DenomMetadata struct {
+	// Base is the base denom, e.g. uatom. For IBC denoms, the base denom on the source chain.
+	Base string
+	// Display is the denom of display units, e.g. atom. Empty if the chain has no metadata for the denom.
+	Display string
+	// Exponent converts base units to display units, i.e. the display amount is the amount / 10^Exponent.
+	Exponent int
+}
+
+// DenomTrace is the origin of an IBC denom.
+type DenomTrace struct {
+	// Path is the channels the denom was transferred through, e.g. transfer/channel-141.
+	Path      string `json:"path"`
+	BaseDenom string `json:"base_denom"`
+}
+
+// DenomsMetadata returns the metadata of all denoms registered with the bank module, keyed by denom.
+// Docs: https://docs.cosmos.network/swagger/#/Query/DenomsMetadata
+func (c RestClient) DenomsMetadata(ctx context.Context) (map[string]DenomMetadata, error) {
+	var (
+		metadata = make(map[string]DenomMetadata)
+		nextKey  string
+	)
+	for {
+		u := url.URL{Path: "/cosmos/bank/v1beta1/denoms_metadata"}
+		q := u.Query()
+		q.Set("pagination.limit", "200")
+		if nextKey != "" {
+			q.Set("pagination.key", nextKey)
+		}
+		u.RawQuery = q.Encode()
+
+		var resp struct {
+			Metadatas []struct {
+				Base       string `json:"base"`
+				Display    string `json:"display"`
+				DenomUnits []struct {
+					Denom    string   `json:"denom"`
+					Exponent int      `json:"exponent"`
+					Aliases  []string `json:"aliases"`
+				} `json:"denom_units"`
+			} `json:"metadatas"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		if err := c.get(ctx, u, &resp); err != nil {
+			return nil, err
+		}
+		for _, m := range resp.Metadatas {
+			md := DenomMetadata{Base: m.Base}
+			// Ignore metadata without a display unit rather than report base units as display units.
+			for _, unit := range m.DenomUnits {
+				if unit.Denom == m.Display {
+					md.Display = m.Display
+					md.Exponent = unit.Exponent
+				}
+			}
+			metadata[m.Base] = md
+		}
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return metadata, nil
+		}
+	}
+}
+
+// DenomTrace returns the origin of an IBC denom given the hash, e.g. the B055... in ibc/B055....
+// Removed in ibc-go v9 in favor of IBCDenom.
+func (c RestClient) DenomTrace(ctx context.Context, hash string) (DenomTrace, error) {
+	var resp struct {
+		DenomTrace DenomTrace `json:"denom_trace"`
+	}
+	err := c.get(ctx, url.URL{Path: path.Join("/ibc/apps/transfer/v1/denom_traces", hash)}, &resp)
+	return resp.DenomTrace, err
+}
+
+// IBCDenom returns the origin of an IBC denom given the hash. Replaces DenomTrace since ibc-go v9.
+func (c RestClient) IBCDenom(ctx context.Context, hash string) (DenomTrace, error) {
+	var resp struct {
+		Denom struct {
+			Base  string `json:"base"`
+			Trace []struct {
+				PortID    string `json:"port_id"`
+				ChannelID string `json:"channel_id"`
+			} `json:"trace"`
+		} `json:"denom"`
+	}
+	if err := c.get(ctx, url.URL{Path: path.Join("/ibc/apps/transfer/v1/denoms", hash)}, &resp); err != nil {
+		return DenomTrace{}, err
+	}
+	hops := make([]string, 0, 2*len(resp.Denom.Trace))
+	for _, hop := range resp.Denom.Trace {
+		hops = append(hops, hop.PortID, hop.ChannelID)
+	}
+	return DenomTrace{Path: strings.Join(hops, "/"), BaseDenom: resp.Denom.Base}, nil
+}
@@ -0,0 +1,77 @@
+package cosmos
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestClient_DenomsMetadata(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		require.Equal(t, "/cosmos/bank/v1beta1/denoms_metadata", path.Path)
+		calls++
+
+		var response string
+		switch calls {
+		case 1:
+			require.Equal(t, "pagination.limit=200", path.RawQuery)
+			response = `{"metadatas":[{"base":"uatom","display":"atom","denom_units":[{"denom":"uatom","exponent":0},{"denom":"atom","exponent":6}]}],"pagination":{"next_key":"bmV4dA=="}}`
+		case 2:
+			require.Equal(t, "bmV4dA==", path.Query().Get("pagination.key"))
+			// The display unit is missing from the denom units.
+			response = `{"metadatas":[{"base":"ufoo","display":"foo","denom_units":[{"denom":"ufoo","exponent":0}]}],"pagination":{"next_key":null}}`
+		default:
+			t.Fatalf("unexpected call %d", calls)
+		}
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(response))}, nil
+	}
+	client := NewRestClient(httpClient)
+	got, err := client.DenomsMetadata(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]DenomMetadata{
+		"uatom": {Base: "uatom", Display: "atom", Exponent: 6},
+		"ufoo":  {Base: "ufoo"},
+	}, got)
+}
+
+func TestRestClient_DenomTrace(t *testing.T) {
+	t.Parallel()
+
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		require.Equal(t, "/ibc/apps/transfer/v1/denom_traces/ABC", path.Path)
+		const response = `{"denom_trace":{"path":"transfer/channel-141","base_denom":"uatom"}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(response))}, nil
+	}
+	client := NewRestClient(httpClient)
+	got, err := client.DenomTrace(context.Background(), "ABC")
+
+	require.NoError(t, err)
+	require.Equal(t, DenomTrace{Path: "transfer/channel-141", BaseDenom: "uatom"}, got)
+}
+
+func TestRestClient_IBCDenom(t *testing.T) {
+	t.Parallel()
+
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		require.Equal(t, "/ibc/apps/transfer/v1/denoms/ABC", path.Path)
+		const response = `{"denom":{"base":"uatom","trace":[{"port_id":"transfer","channel_id":"channel-141"},{"port_id":"transfer","channel_id":"channel-0"}]}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(response))}, nil
+	}
+	client := NewRestClient(httpClient)
+	got, err := client.IBCDenom(context.Background(), "ABC")
+
+	require.NoError(t, err)
+	require.Equal(t, DenomTrace{Path: "transfer/channel-141/transfer/channel-0", BaseDenom: "uatom"}, got)
+}
@@ -0,0 +1,107 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// GovVersion is the version of the gov module REST API. Chains on older SDK versions only support v1beta1.
+type GovVersion string
+
+const (
+	GovV1      GovVersion = "v1"
+	GovV1Beta1 GovVersion = "v1beta1"
+)
+
+// Proposal is a governance proposal normalized across gov API versions.
+type Proposal struct {
+	ID            uint64
+	Title         string
+	VotingEndTime time.Time
+}
+
+// VotingProposals returns all proposals currently in the voting period.
+// Docs: https://docs.cosmos.network/swagger/#/Query/Proposals
+func (c RestClient) VotingProposals(ctx context.Context, version GovVersion) ([]Proposal, error) {
+	var (
+		proposals []Proposal
+		nextKey   string
+	)
+	for {
+		u := url.URL{Path: path.Join("/cosmos/gov", string(version), "proposals")}
+		q := u.Query()
+		q.Set("proposal_status", "PROPOSAL_STATUS_VOTING_PERIOD")
+		if nextKey != "" {
+			q.Set("pagination.key", nextKey)
+		}
+		u.RawQuery = q.Encode()
+
+		// Fields are the union of v1 and v1beta1 responses.
+		var resp struct {
+			Proposals []struct {
+				ID         string `json:"id"`          // v1
+				ProposalID string `json:"proposal_id"` // v1beta1
+				Title      string `json:"title"`       // v1, SDK v0.47+
+				Content    struct {
+					Title string `json:"title"`
+				} `json:"content"` // v1beta1
+				VotingEndTime time.Time `json:"voting_end_time"`
+			} `json:"proposals"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		if err := c.get(ctx, u, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, p := range resp.Proposals {
+			rawID := p.ID
+			if rawID == "" {
+				rawID = p.ProposalID
+			}
+			id, err := strconv.ParseUint(rawID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed proposal id: %w", err)
+			}
+			title := p.Title
+			if title == "" {
+				title = p.Content.Title
+			}
+			proposals = append(proposals, Proposal{
+				ID:            id,
+				Title:         title,
+				VotingEndTime: p.VotingEndTime,
+			})
+		}
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return proposals, nil
+		}
+	}
+}
+
+// HasVoted returns true if the voter (an account address) has voted on the proposal.
+// Docs: https://docs.cosmos.network/swagger/#/Query/Vote
+func (c RestClient) HasVoted(ctx context.Context, version GovVersion, proposalID uint64, voter string) (bool, error) {
+	p := path.Join("/cosmos/gov", string(version), "proposals", strconv.FormatUint(proposalID, 10), "votes", voter)
+	var resp struct {
+		Vote struct {
+			Voter string `json:"voter"`
+		} `json:"vote"`
+	}
+	err := c.get(ctx, url.URL{Path: p}, &resp)
+	// Other client errors, e.g. a malformed voter address, are not mistaken for a missing vote.
+	if isNotFoundError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return resp.Vote.Voter != "", nil
+}
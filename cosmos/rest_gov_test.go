@@ -0,0 +1,198 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockStatusError int
+
+func (e mockStatusError) Error() string   { return fmt.Sprintf("bad status code %d", e) }
+func (e mockStatusError) StatusCode() int { return int(e) }
+
+type mockNotFoundError int
+
+func (e mockNotFoundError) Error() string   { return fmt.Sprintf("bad status code %d", e) }
+func (e mockNotFoundError) StatusCode() int { return int(e) }
+func (e mockNotFoundError) NotFound() bool  { return true }
+
+func TestRestClient_VotingProposals(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("v1", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		var callCount int
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, "/cosmos/gov/v1/proposals", path.Path)
+			require.Equal(t, "PROPOSAL_STATUS_VOTING_PERIOD", path.Query().Get("proposal_status"))
+
+			callCount++
+			var response string
+			switch callCount {
+			case 1:
+				response = `{
+  "proposals": [
+    {
+      "id": "850",
+      "status": "PROPOSAL_STATUS_VOTING_PERIOD",
+      "voting_end_time": "2023-11-20T15:40:11.158465592Z",
+      "title": "Signaling Proposal"
+    }
+  ],
+  "pagination": {"next_key": "AAAAAAAAA1M=", "total": "0"}
+}`
+			case 2:
+				require.Equal(t, "AAAAAAAAA1M=", path.Query().Get("pagination.key"))
+				response = `{"proposals": [{"id": "851", "voting_end_time": "2023-11-21T15:40:11Z"}], "pagination": {"next_key": null}}`
+			default:
+				panic("unexpected call")
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(response)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.VotingProposals(ctx, GovV1)
+		require.NoError(t, err)
+
+		require.Equal(t, []Proposal{
+			{ID: 850, Title: "Signaling Proposal", VotingEndTime: time.Date(2023, time.November, 20, 15, 40, 11, 158465592, time.UTC)},
+			{ID: 851, VotingEndTime: time.Date(2023, time.November, 21, 15, 40, 11, 0, time.UTC)},
+		}, got)
+	})
+
+	t.Run("v1beta1", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.Equal(t, "/cosmos/gov/v1beta1/proposals", path.Path)
+
+			const response = `{
+  "proposals": [
+    {
+      "proposal_id": "12",
+      "content": {
+        "@type": "/cosmos.gov.v1beta1.TextProposal",
+        "title": "Text Proposal",
+        "description": "Description"
+      },
+      "status": "PROPOSAL_STATUS_VOTING_PERIOD",
+      "voting_end_time": "2023-11-20T15:40:11Z"
+    }
+  ],
+  "pagination": {"next_key": null, "total": "1"}
+}`
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(response)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.VotingProposals(ctx, GovV1Beta1)
+		require.NoError(t, err)
+
+		require.Equal(t, []Proposal{
+			{ID: 12, Title: "Text Proposal", VotingEndTime: time.Date(2023, time.November, 20, 15, 40, 11, 0, time.UTC)},
+		}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, _ url.URL) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}
+		client := NewRestClient(&httpClient)
+
+		_, err := client.VotingProposals(ctx, GovV1)
+
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestRestClient_HasVoted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("voted", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, "/cosmos/gov/v1/proposals/850/votes/cosmos123", path.Path)
+
+			const response = `{
+  "vote": {
+    "proposal_id": "850",
+    "voter": "cosmos123",
+    "options": [{"option": "VOTE_OPTION_YES", "weight": "1.000000000000000000"}],
+    "metadata": ""
+  }
+}`
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(response)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.HasVoted(ctx, GovV1, 850, "cosmos123")
+
+		require.NoError(t, err)
+		require.True(t, got)
+	})
+
+	t.Run("not voted", func(t *testing.T) {
+		for _, tt := range []mockNotFoundError{http.StatusBadRequest, http.StatusNotFound} {
+			var httpClient mockHTTPClient
+			httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+				require.Equal(t, "/cosmos/gov/v1beta1/proposals/12/votes/cosmos123", path.Path)
+				return nil, fmt.Errorf("wrapped: %w", tt)
+			}
+			client := NewRestClient(httpClient)
+			got, err := client.HasVoted(ctx, GovV1Beta1, 12, "cosmos123")
+
+			require.NoError(t, err, tt)
+			require.False(t, got, tt)
+		}
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		for _, tt := range []error{
+			mockStatusError(http.StatusBadRequest),
+			mockStatusError(http.StatusNotFound),
+		} {
+			var httpClient mockHTTPClient
+			httpClient.GetFn = func(ctx context.Context, _ url.URL) (*http.Response, error) {
+				return nil, tt
+			}
+			client := NewRestClient(httpClient)
+
+			_, err := client.HasVoted(ctx, GovV1, 1, "cosmos123")
+
+			require.Error(t, err, tt)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, _ url.URL) (*http.Response, error) {
+			return nil, mockStatusError(http.StatusInternalServerError)
+		}
+		client := NewRestClient(&httpClient)
+
+		_, err := client.HasVoted(ctx, GovV1, 1, "cosmos123")
+
+		require.EqualError(t, err, "bad status code 500")
+	})
+}
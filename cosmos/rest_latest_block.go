@@ -2,7 +2,10 @@ package cosmos
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
+	"path"
+	"strconv"
 	"time"
 )
 
@@ -55,19 +58,74 @@ type Block struct {
 					Hash  string `json:"hash"`
 				} `json:"parts"`
 			} `json:"block_id"`
-			Signatures []struct {
-				BlockIDFlag      string    `json:"block_id_flag"`
-				ValidatorAddress string    `json:"validator_address"`
-				Timestamp        time.Time `json:"timestamp"`
-				Signature        string    `json:"signature"`
-			} `json:"signatures"`
+			Signatures []CommitSig `json:"signatures"`
 		} `json:"last_commit"`
 	} `json:"block"`
 }
 
+// CommitSig is a validator's signature in a block's last commit.
+type CommitSig struct {
+	BlockIDFlag      BlockIDFlag `json:"block_id_flag"`
+	ValidatorAddress string      `json:"validator_address"`
+	Timestamp        time.Time   `json:"timestamp"`
+	Signature        string      `json:"signature"`
+}
+
+// BlockIDFlag indicates whether a validator's signature is for the block, nil or absent.
+// The REST API encodes the flag as a string, e.g. BLOCK_ID_FLAG_COMMIT. The CometBFT RPC encodes it as an integer.
+type BlockIDFlag string
+
+const (
+	BlockIDFlagUnknown BlockIDFlag = "BLOCK_ID_FLAG_UNKNOWN"
+	BlockIDFlagAbsent  BlockIDFlag = "BLOCK_ID_FLAG_ABSENT"
+	BlockIDFlagCommit  BlockIDFlag = "BLOCK_ID_FLAG_COMMIT"
+	BlockIDFlagNil     BlockIDFlag = "BLOCK_ID_FLAG_NIL"
+)
+
+// UnmarshalJSON accepts both the string and integer encodings.
+func (f *BlockIDFlag) UnmarshalJSON(b []byte) error {
+	var n int
+	if err := json.Unmarshal(b, &n); err == nil {
+		*f = blockIDFlagFromInt(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*f = BlockIDFlag(s)
+	return nil
+}
+
+func blockIDFlagFromInt(n int) BlockIDFlag {
+	switch n {
+	case 1:
+		return BlockIDFlagAbsent
+	case 2:
+		return BlockIDFlagCommit
+	case 3:
+		return BlockIDFlagNil
+	}
+	return BlockIDFlagUnknown
+}
+
+// Height parses the block's header height.
+func (b Block) Height() (int64, error) {
+	return strconv.ParseInt(b.Block.Header.Height, 10, 64)
+}
+
 // LatestBlock queries the latest block from the Cosmos REST API given the baseURL.
 func (c RestClient) LatestBlock(ctx context.Context) (Block, error) {
 	var latestBlock Block
 	err := c.get(ctx, url.URL{Path: "/cosmos/base/tendermint/v1beta1/blocks/latest"}, &latestBlock)
 	return latestBlock, err
 }
+
+// BlockByHeight queries the block at height from the Cosmos REST API.
+// Nodes prune old blocks, so requesting a height far behind the tip likely returns an error.
+func (c RestClient) BlockByHeight(ctx context.Context, height int64) (Block, error) {
+	var block Block
+	p := path.Join("/cosmos/base/tendermint/v1beta1/blocks", strconv.FormatInt(height, 10))
+	err := c.get(ctx, url.URL{Path: p}, &block)
+	return block, err
+}
@@ -65,3 +65,39 @@ func TestClient_LatestBlock(t *testing.T) {
 		require.EqualError(t, err, "boom")
 	})
 }
+
+func TestClient_BlockByHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, "/cosmos/base/tendermint/v1beta1/blocks/15312655", path.Path)
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(latestBlockFixture)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.BlockByHeight(ctx, 15312655)
+
+		require.NoError(t, err)
+		require.Equal(t, "15312655", got.Block.Header.Height)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}
+		client := NewRestClient(&httpClient)
+
+		_, err := client.BlockByHeight(ctx, 1)
+
+		require.EqualError(t, err, "boom")
+	})
+}
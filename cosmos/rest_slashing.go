@@ -2,6 +2,7 @@ package cosmos
 
 import (
 	"context"
+	"math"
 	"net/url"
 	"path"
 	"strconv"
@@ -44,6 +45,36 @@ func (s SlashingParams) SignedBlocksWindow() float64 {
 	return v
 }
 
+// MinSignedPerWindow is the minimum ratio of blocks a validator must sign within the window to avoid jailing.
+func (s SlashingParams) MinSignedPerWindow() float64 {
+	v, _ := strconv.ParseFloat(s.Params.MinSignedPerWindow, 64)
+	return v
+}
+
+func (s SlashingParams) DowntimeJailDuration() time.Duration {
+	v, _ := time.ParseDuration(s.Params.DowntimeJailDuration)
+	return v
+}
+
+func (s SlashingParams) SlashFractionDoubleSign() float64 {
+	v, _ := strconv.ParseFloat(s.Params.SlashFractionDoubleSign, 64)
+	return v
+}
+
+func (s SlashingParams) SlashFractionDowntime() float64 {
+	v, _ := strconv.ParseFloat(s.Params.SlashFractionDowntime, 64)
+	return v
+}
+
+// MissedBlocksUntilJail returns how many more blocks a validator may miss within the window before it is jailed.
+// The calculation mirrors the x/slashing module which jails once missed blocks exceed
+// the window minus the minimum signed blocks (rounded).
+func (s SlashingParams) MissedBlocksUntilJail(missed float64) float64 {
+	window := s.SignedBlocksWindow()
+	maxMissed := window - math.Round(window*s.MinSignedPerWindow())
+	return math.Max(maxMissed-missed, 0)
+}
+
 // SlashingParams returns the slashing parameters.
 // Docs: https://docs.cosmos.network/swagger/#/Query/SlashingParams
 func (c RestClient) SlashingParams(ctx context.Context) (SlashingParams, error) {
@@ -78,4 +78,23 @@ func TestRestClient_SlashingParams(t *testing.T) {
 
 	require.Equal(t, want, got)
 	require.Equal(t, 10000.0, got.SignedBlocksWindow())
+	require.Equal(t, 0.05, got.MinSignedPerWindow())
+	require.Equal(t, 10*time.Minute, got.DowntimeJailDuration())
+	require.Equal(t, 0.05, got.SlashFractionDoubleSign())
+	require.Equal(t, 0.0001, got.SlashFractionDowntime())
+}
+
+func TestSlashingParams_MissedBlocksUntilJail(t *testing.T) {
+	t.Parallel()
+
+	var params SlashingParams
+	params.Params.SignedBlocksWindow = "10000"
+	params.Params.MinSignedPerWindow = "0.050000000000000000"
+
+	require.Equal(t, 9500.0, params.MissedBlocksUntilJail(0))
+	require.Equal(t, 500.0, params.MissedBlocksUntilJail(9000))
+	require.Equal(t, 0.0, params.MissedBlocksUntilJail(9500))
+	require.Equal(t, 0.0, params.MissedBlocksUntilJail(9999))
+
+	require.Zero(t, SlashingParams{}.MissedBlocksUntilJail(0))
 }
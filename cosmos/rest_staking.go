@@ -0,0 +1,150 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// BondStatus is the staking status of a validator.
+type BondStatus int
+
+const (
+	BondStatusUnspecified BondStatus = iota
+	BondStatusUnbonded
+	BondStatusUnbonding
+	BondStatusBonded
+)
+
+// StakingValidator is a validator's record from the staking module.
+type StakingValidator struct {
+	OperatorAddress string `json:"operator_address"`
+	ConsensusPubkey struct {
+		Type string `json:"@type"`
+		Key  string `json:"key"`
+	} `json:"consensus_pubkey"`
+	Jailed          bool   `json:"jailed"`
+	Status          string `json:"status"`
+	Tokens          string `json:"tokens"`
+	DelegatorShares string `json:"delegator_shares"`
+	Description     struct {
+		Moniker string `json:"moniker"`
+	} `json:"description"`
+	Commission struct {
+		CommissionRates struct {
+			Rate          string `json:"rate"`
+			MaxRate       string `json:"max_rate"`
+			MaxChangeRate string `json:"max_change_rate"`
+		} `json:"commission_rates"`
+		UpdateTime time.Time `json:"update_time"`
+	} `json:"commission"`
+}
+
+func (v StakingValidator) BondStatus() BondStatus {
+	switch v.Status {
+	case "BOND_STATUS_UNBONDED":
+		return BondStatusUnbonded
+	case "BOND_STATUS_UNBONDING":
+		return BondStatusUnbonding
+	case "BOND_STATUS_BONDED":
+		return BondStatusBonded
+	}
+	return BondStatusUnspecified
+}
+
+func (v StakingValidator) TokensAmount() float64 {
+	f, _ := strconv.ParseFloat(v.Tokens, 64)
+	return f
+}
+
+func (v StakingValidator) DelegatorSharesAmount() float64 {
+	f, _ := strconv.ParseFloat(v.DelegatorShares, 64)
+	return f
+}
+
+func (v StakingValidator) CommissionRate() float64 {
+	f, _ := strconv.ParseFloat(v.Commission.CommissionRates.Rate, 64)
+	return f
+}
+
+func (v StakingValidator) CommissionMaxRate() float64 {
+	f, _ := strconv.ParseFloat(v.Commission.CommissionRates.MaxRate, 64)
+	return f
+}
+
+// ConsAddress derives the bech32 consensus address from the consensus pubkey.
+// The bech32 prefix is derived from the operator address, e.g. cosmosvaloper becomes cosmosvalcons.
+// Only ed25519 consensus keys are supported which is the default for CometBFT.
+func (v StakingValidator) ConsAddress() (string, error) {
+	hrp, _, err := bech32.DecodeAndConvert(v.OperatorAddress)
+	if err != nil {
+		return "", fmt.Errorf("decode operator address: %w", err)
+	}
+	if !strings.HasSuffix(hrp, "valoper") {
+		return "", fmt.Errorf("unexpected operator address prefix %q", hrp)
+	}
+	if typ := v.ConsensusPubkey.Type; typ != "/cosmos.crypto.ed25519.PubKey" {
+		return "", fmt.Errorf("unsupported consensus pubkey type %q", typ)
+	}
+	key, err := base64.StdEncoding.DecodeString(v.ConsensusPubkey.Key)
+	if err != nil {
+		return "", fmt.Errorf("decode consensus pubkey: %w", err)
+	}
+	// An ed25519 address is the first 20 bytes of the SHA256 hash of the pubkey.
+	sum := sha256.Sum256(key)
+	return bech32.ConvertAndEncode(strings.TrimSuffix(hrp, "valoper")+"valcons", sum[:20])
+}
+
+// StakingValidator returns the staking record of a validator given the operator address.
+// Docs: https://docs.cosmos.network/swagger/#/Query/Validator
+func (c RestClient) StakingValidator(ctx context.Context, valoper string) (StakingValidator, error) {
+	p := path.Join("/cosmos/staking/v1beta1/validators", valoper)
+	var resp struct {
+		Validator StakingValidator `json:"validator"`
+	}
+	err := c.get(ctx, url.URL{Path: p}, &resp)
+	return resp.Validator, err
+}
+
+// BondedValidators returns all validators in the active set in no particular order.
+// Docs: https://docs.cosmos.network/swagger/#/Query/Validators
+func (c RestClient) BondedValidators(ctx context.Context) ([]StakingValidator, error) {
+	var (
+		vals    []StakingValidator
+		nextKey string
+	)
+	for {
+		u := url.URL{Path: "/cosmos/staking/v1beta1/validators"}
+		q := u.Query()
+		q.Set("status", "BOND_STATUS_BONDED")
+		q.Set("pagination.limit", "200")
+		if nextKey != "" {
+			q.Set("pagination.key", nextKey)
+		}
+		u.RawQuery = q.Encode()
+
+		var resp struct {
+			Validators []StakingValidator `json:"validators"`
+			Pagination struct {
+				NextKey string `json:"next_key"`
+			} `json:"pagination"`
+		}
+		if err := c.get(ctx, u, &resp); err != nil {
+			return nil, err
+		}
+		vals = append(vals, resp.Validators...)
+
+		nextKey = resp.Pagination.NextKey
+		if nextKey == "" {
+			return vals, nil
+		}
+	}
+}
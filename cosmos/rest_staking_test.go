@@ -0,0 +1,153 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const stakingValidatorFixture = `{
+  "operator_address": "cosmosvaloper123",
+  "consensus_pubkey": {
+    "@type": "/cosmos.crypto.ed25519.PubKey",
+    "key": "Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM="
+  },
+  "jailed": false,
+  "status": "BOND_STATUS_BONDED",
+  "tokens": "5019254718410",
+  "delegator_shares": "5019254718410.000000000000000000",
+  "description": {
+    "moniker": "Strangelove"
+  },
+  "commission": {
+    "commission_rates": {
+      "rate": "0.050000000000000000",
+      "max_rate": "0.200000000000000000",
+      "max_change_rate": "0.010000000000000000"
+    },
+    "update_time": "2023-01-10T15:03:07.469433426Z"
+  }
+}`
+
+func TestRestClient_StakingValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, "/cosmos/staking/v1beta1/validators/cosmosvaloper123", path.Path)
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"validator": ` + stakingValidatorFixture + `}`)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.StakingValidator(ctx, "cosmosvaloper123")
+		require.NoError(t, err)
+
+		require.Equal(t, "cosmosvaloper123", got.OperatorAddress)
+		require.Equal(t, "Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM=", got.ConsensusPubkey.Key)
+		require.Equal(t, BondStatusBonded, got.BondStatus())
+		require.Equal(t, 5019254718410.0, got.TokensAmount())
+		require.Equal(t, 5019254718410.0, got.DelegatorSharesAmount())
+		require.Equal(t, 0.05, got.CommissionRate())
+		require.Equal(t, 0.2, got.CommissionMaxRate())
+		require.Equal(t, time.Date(2023, time.January, 10, 15, 3, 7, 469433426, time.UTC), got.Commission.UpdateTime)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, _ url.URL) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}
+		client := NewRestClient(&httpClient)
+
+		_, err := client.StakingValidator(ctx, "cosmosvaloper123")
+
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestRestClient_BondedValidators(t *testing.T) {
+	t.Parallel()
+
+	var httpClient mockHTTPClient
+	var callCount int
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		require.NotNil(t, ctx)
+		require.Equal(t, "/cosmos/staking/v1beta1/validators", path.Path)
+		require.Equal(t, "BOND_STATUS_BONDED", path.Query().Get("status"))
+
+		callCount++
+		var response string
+		switch callCount {
+		case 1:
+			require.Empty(t, path.Query().Get("pagination.key"))
+			response = `{"validators": [` + stakingValidatorFixture + `], "pagination": {"next_key": "abc="}}`
+		case 2:
+			require.Equal(t, "abc=", path.Query().Get("pagination.key"))
+			response = `{"validators": [{"operator_address": "cosmosvaloper456"}], "pagination": {"next_key": null}}`
+		default:
+			panic("unexpected call")
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(response)),
+		}, nil
+	}
+	client := NewRestClient(httpClient)
+	got, err := client.BondedValidators(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	require.Equal(t, "cosmosvaloper123", got[0].OperatorAddress)
+	require.Equal(t, "cosmosvaloper456", got[1].OperatorAddress)
+}
+
+func TestStakingValidator_ConsAddress(t *testing.T) {
+	t.Parallel()
+
+	newVal := func(valoper, typ string) StakingValidator {
+		var val StakingValidator
+		val.OperatorAddress = valoper
+		val.ConsensusPubkey.Type = typ
+		val.ConsensusPubkey.Key = "Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM="
+		return val
+	}
+
+	const valoper = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+
+	t.Run("happy path", func(t *testing.T) {
+		got, err := newVal(valoper, "/cosmos.crypto.ed25519.PubKey").ConsAddress()
+
+		require.NoError(t, err)
+		require.Equal(t, "cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g", got)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			Val     StakingValidator
+			WantErr string
+		}{
+			{newVal("", "/cosmos.crypto.ed25519.PubKey"), "decode operator address"},
+			{newVal("cosmos1xyerxdp4xcmnswfsxyerxdp4xcmnswfs2mnmda", "/cosmos.crypto.ed25519.PubKey"), "unexpected operator address prefix"},
+			{newVal(valoper, "/cosmos.crypto.secp256k1.PubKey"), "unsupported consensus pubkey type"},
+		} {
+			_, err := tt.Val.ConsAddress()
+
+			require.Error(t, err, tt)
+			require.Contains(t, err.Error(), tt.WantErr, tt)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package cosmos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// UpgradePlan is a scheduled software upgrade.
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+// HeightValue parses the upgrade height. Returns 0 if the plan is not height based.
+func (p UpgradePlan) HeightValue() int64 {
+	v, _ := strconv.ParseInt(p.Height, 10, 64)
+	return v
+}
+
+// UpgradePlan returns the currently scheduled upgrade plan or nil if no upgrade is scheduled.
+// Docs: https://docs.cosmos.network/swagger/#/Query/CurrentPlan
+func (c RestClient) UpgradePlan(ctx context.Context) (*UpgradePlan, error) {
+	var resp struct {
+		Plan *UpgradePlan `json:"plan"`
+	}
+	err := c.get(ctx, url.URL{Path: "/cosmos/upgrade/v1beta1/current_plan"}, &resp)
+	return resp.Plan, err
+}
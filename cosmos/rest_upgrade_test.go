@@ -0,0 +1,75 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestClient_UpgradePlan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, "/cosmos/upgrade/v1beta1/current_plan", path.Path)
+
+			const response = `{
+  "plan": {
+    "name": "v15",
+    "time": "0001-01-01T00:00:00Z",
+    "height": "18791940",
+    "info": "{\"binaries\":{}}",
+    "upgraded_client_state": null
+  }
+}`
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(response)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.UpgradePlan(ctx)
+
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		require.Equal(t, "v15", got.Name)
+		require.Equal(t, int64(18791940), got.HeightValue())
+	})
+
+	t.Run("no plan", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`{"plan": null}`)),
+			}, nil
+		}
+		client := NewRestClient(httpClient)
+		got, err := client.UpgradePlan(ctx)
+
+		require.NoError(t, err)
+		require.Nil(t, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, _ url.URL) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}
+		client := NewRestClient(&httpClient)
+
+		_, err := client.UpgradePlan(ctx)
+
+		require.EqualError(t, err, "boom")
+	})
+}
@@ -0,0 +1,57 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// LatestBlock queries the latest block from the CometBFT RPC.
+// Docs: https://docs.cometbft.com/v0.38/rpc/#/Info/block
+func (c RPCClient) LatestBlock(ctx context.Context) (Block, error) {
+	return c.block(ctx, url.Values{})
+}
+
+// BlockByHeight queries the block at height from the CometBFT RPC.
+// Nodes prune old blocks, so requesting a height far behind the tip likely returns an error.
+func (c RPCClient) BlockByHeight(ctx context.Context, height int64) (Block, error) {
+	return c.block(ctx, url.Values{"height": {strconv.FormatInt(height, 10)}})
+}
+
+func (c RPCClient) block(ctx context.Context, query url.Values) (Block, error) {
+	var block Block
+	err := c.get(ctx, url.URL{Path: "/block", RawQuery: query.Encode()}, &block)
+	if err != nil {
+		return block, err
+	}
+	err = normalizeRPCBlock(&block)
+	return block, err
+}
+
+// normalizeRPCBlock converts a block decoded from the RPC to the REST API's encoding.
+// The RPC encodes addresses as hex while the REST API, and therefore Block, uses base64.
+func normalizeRPCBlock(block *Block) error {
+	var err error
+	header := &block.Block.Header
+	if header.ProposerAddress, err = hexToBase64(header.ProposerAddress); err != nil {
+		return fmt.Errorf("malformed proposer address: %w", err)
+	}
+	sigs := block.Block.LastCommit.Signatures
+	for i := range sigs {
+		if sigs[i].ValidatorAddress, err = hexToBase64(sigs[i].ValidatorAddress); err != nil {
+			return fmt.Errorf("malformed validator address: %w", err)
+		}
+	}
+	return nil
+}
+
+func hexToBase64(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
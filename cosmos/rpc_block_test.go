@@ -0,0 +1,123 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/rpc_block.json
+var rpcBlockFixture []byte
+
+func TestRPCClient_LatestBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, "/block", path.Path)
+			require.Empty(t, path.RawQuery)
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewReader(rpcBlockFixture)),
+			}, nil
+		}
+		client := NewRPCClient(httpClient)
+		got, err := client.LatestBlock(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, "15312655", got.Block.Header.Height)
+		require.Equal(t, "cosmoshub-4", got.Block.Header.ChainID)
+		require.Equal(t, "1o7sDS6CSPHsZM21he22HspDK9g=", got.Block.Header.ProposerAddress)
+		require.Equal(t, "15312654", got.Block.LastCommit.Height)
+
+		sigs := got.Block.LastCommit.Signatures
+		require.Len(t, sigs, 2)
+		require.Equal(t, BlockIDFlagCommit, sigs[0].BlockIDFlag)
+		require.Equal(t, "1o7sDS6CSPHsZM21he22HspDK9g=", sigs[0].ValidatorAddress)
+		require.Equal(t, BlockIDFlagAbsent, sigs[1].BlockIDFlag)
+		require.Empty(t, sigs[1].ValidatorAddress)
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			const resp = `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"boom"}}`
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(resp)),
+			}, nil
+		}
+		client := NewRPCClient(httpClient)
+		_, err := client.LatestBlock(ctx)
+
+		require.EqualError(t, err, "rpc error -32603: Internal error: boom")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			return nil, errors.New("boom")
+		}
+		client := NewRPCClient(httpClient)
+		_, err := client.LatestBlock(ctx)
+
+		require.EqualError(t, err, "boom")
+	})
+}
+
+func TestRPCClient_BlockByHeight(t *testing.T) {
+	t.Parallel()
+
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		require.Equal(t, "/block", path.Path)
+		require.Equal(t, "15312655", path.Query().Get("height"))
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader(rpcBlockFixture)),
+		}, nil
+	}
+	client := NewRPCClient(httpClient)
+	got, err := client.BlockByHeight(context.Background(), 15312655)
+
+	require.NoError(t, err)
+	height, err := got.Height()
+	require.NoError(t, err)
+	require.EqualValues(t, 15312655, height)
+}
+
+func TestBlockIDFlag_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		JSON string
+		Want BlockIDFlag
+	}{
+		{`"BLOCK_ID_FLAG_COMMIT"`, BlockIDFlagCommit},
+		{`1`, BlockIDFlagAbsent},
+		{`2`, BlockIDFlagCommit},
+		{`3`, BlockIDFlagNil},
+		{`0`, BlockIDFlagUnknown},
+	} {
+		var got BlockIDFlag
+		require.NoError(t, got.UnmarshalJSON([]byte(tt.JSON)), tt.JSON)
+		require.Equal(t, tt.Want, got, tt.JSON)
+	}
+
+	var flag BlockIDFlag
+	require.Error(t, flag.UnmarshalJSON([]byte(`{}`)))
+}
@@ -0,0 +1,88 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RPCClient is a client for the CometBFT RPC, typically on port 26657.
+// Block data is queried directly from CometBFT. Module state, such as signing info, is queried through
+// the application via abci_query, because CometBFT endpoints such as /validators only know public keys and
+// voting power, not missed block counters, jail status, slashing params or staking records.
+// Docs: https://docs.cometbft.com/v0.38/rpc/
+type RPCClient struct {
+	protoClient
+	client HTTPClient
+}
+
+func NewRPCClient(c HTTPClient) *RPCClient {
+	client := &RPCClient{
+		client: c,
+	}
+	client.protoClient = protoClient{query: client.abciQuery}
+	return client
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s: %s", e.Code, e.Message, e.Data)
+}
+
+// result must be a pointer to a datatype (typically a struct)
+func (c RPCClient) get(ctx context.Context, path url.URL, result any) error {
+	resp, err := c.client.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("malformed json: %w", err)
+	}
+	if envelope.Error != nil {
+		return *envelope.Error
+	}
+	if err = json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("malformed json: %w", err)
+	}
+	return nil
+}
+
+// abciQuery queries the application given a gRPC method path, e.g. /cosmos.slashing.v1beta1.Query/Params,
+// and a protobuf encoded request. Returns the protobuf encoded response.
+func (c RPCClient) abciQuery(ctx context.Context, method string, data []byte) ([]byte, error) {
+	u := url.URL{Path: "/abci_query"}
+	q := u.Query()
+	// Query params are JSON encoded, so strings must be quoted.
+	q.Set("path", strconv.Quote(method))
+	q.Set("data", "0x"+hex.EncodeToString(data))
+	u.RawQuery = q.Encode()
+
+	var resp struct {
+		Response struct {
+			Code  uint32 `json:"code"`
+			Log   string `json:"log"`
+			Value []byte `json:"value"`
+		} `json:"response"`
+	}
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Response.Code != 0 {
+		return nil, fmt.Errorf("abci query %s failed with code %d: %s", method, resp.Response.Code, resp.Response.Log)
+	}
+	return resp.Response.Value, nil
+}
@@ -0,0 +1,216 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func abciResponse(code int, value []byte) *http.Response {
+	resp := fmt.Sprintf(`{"jsonrpc":"2.0","id":-1,"result":{"response":{"code":%d,"log":"not found","value":%q}}}`,
+		code, base64.StdEncoding.EncodeToString(value))
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(resp)),
+	}
+}
+
+// requireABCIQuery asserts the request is an abci_query and returns the protobuf encoded request data.
+func requireABCIQuery(t *testing.T, path url.URL, method string) []byte {
+	t.Helper()
+	require.Equal(t, "/abci_query", path.Path)
+	require.Equal(t, strconv.Quote(method), path.Query().Get("path"))
+	data := path.Query().Get("data")
+	require.True(t, strings.HasPrefix(data, "0x"), data)
+	b, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	require.NoError(t, err)
+	return b
+}
+
+func protoAppendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func protoTimestamp(t time.Time) []byte {
+	b := protoAppendVarint(nil, 1, uint64(t.Unix()))
+	return protoAppendVarint(b, 2, uint64(t.Nanosecond()))
+}
+
+func protoValidator(valoper, tokens string) []byte {
+	key, _ := base64.StdEncoding.DecodeString("Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM=")
+	pubkey := protoAppendString(nil, 1, "/cosmos.crypto.ed25519.PubKey")
+	pubkey = protoAppendBytes(pubkey, 2, protoAppendBytes(nil, 1, key))
+
+	rates := protoAppendString(nil, 1, "50000000000000000")
+	rates = protoAppendString(rates, 2, "200000000000000000")
+	rates = protoAppendString(rates, 3, "10000000000000000")
+	commission := protoAppendBytes(nil, 1, rates)
+
+	val := protoAppendString(nil, 1, valoper)
+	val = protoAppendBytes(val, 2, pubkey)
+	val = protoAppendVarint(val, 3, 0)
+	val = protoAppendVarint(val, 4, 3)
+	val = protoAppendString(val, 5, tokens)
+	val = protoAppendString(val, 6, tokens+"000000000000000000")
+	val = protoAppendBytes(val, 7, protoAppendString(nil, 1, "Test Validator"))
+	val = protoAppendBytes(val, 10, commission)
+	return val
+}
+
+func TestRPCClient_SigningInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const consaddress = "cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g"
+
+	t.Run("happy path", func(t *testing.T) {
+		jailedUntil := time.Date(2023, 5, 12, 18, 31, 41, 500, time.UTC)
+		info := protoAppendString(nil, 1, consaddress)
+		info = protoAppendVarint(info, 2, 100)
+		info = protoAppendVarint(info, 3, 200)
+		info = protoAppendBytes(info, 4, protoTimestamp(jailedUntil))
+		info = protoAppendVarint(info, 5, 1)
+		info = protoAppendVarint(info, 6, 42)
+
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			req := requireABCIQuery(t, path, "/cosmos.slashing.v1beta1.Query/SigningInfo")
+			require.Equal(t, protoAppendString(nil, 1, consaddress), req)
+			return abciResponse(0, protoAppendBytes(nil, 1, info)), nil
+		}
+		client := NewRPCClient(httpClient)
+		got, err := client.SigningInfo(ctx, consaddress)
+
+		require.NoError(t, err)
+		require.Equal(t, consaddress, got.ValSigningInfo.Address)
+		require.Equal(t, "100", got.ValSigningInfo.StartHeight)
+		require.Equal(t, "200", got.ValSigningInfo.IndexOffset)
+		require.Equal(t, jailedUntil, got.ValSigningInfo.JailedUntil)
+		require.True(t, got.ValSigningInfo.Tombstoned)
+		require.Equal(t, "42", got.ValSigningInfo.MissedBlocksCounter)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			return abciResponse(22, nil), nil
+		}
+		client := NewRPCClient(httpClient)
+		_, err := client.SigningInfo(ctx, consaddress)
+
+		require.EqualError(t, err, "abci query /cosmos.slashing.v1beta1.Query/SigningInfo failed with code 22: not found")
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		var httpClient mockHTTPClient
+		httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+			return abciResponse(0, nil), nil
+		}
+		client := NewRPCClient(httpClient)
+		_, err := client.SigningInfo(ctx, consaddress)
+
+		require.EqualError(t, err, "missing signing info")
+	})
+}
+
+func TestRPCClient_SlashingParams(t *testing.T) {
+	t.Parallel()
+
+	duration := protoAppendVarint(nil, 1, 600)
+	params := protoAppendVarint(nil, 1, 10000)
+	params = protoAppendBytes(params, 2, []byte("50000000000000000"))
+	params = protoAppendBytes(params, 3, duration)
+	params = protoAppendBytes(params, 4, []byte("50000000000000000"))
+	params = protoAppendBytes(params, 5, []byte("100000000000000"))
+
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		req := requireABCIQuery(t, path, "/cosmos.slashing.v1beta1.Query/Params")
+		require.Empty(t, req)
+		return abciResponse(0, protoAppendBytes(nil, 1, params)), nil
+	}
+	client := NewRPCClient(httpClient)
+	got, err := client.SlashingParams(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 10000.0, got.SignedBlocksWindow())
+	require.Equal(t, 0.05, got.MinSignedPerWindow())
+	require.Equal(t, 10*time.Minute, got.DowntimeJailDuration())
+	require.Equal(t, 0.05, got.SlashFractionDoubleSign())
+	require.Equal(t, 0.0001, got.SlashFractionDowntime())
+}
+
+func TestRPCClient_StakingValidator(t *testing.T) {
+	t.Parallel()
+
+	const valoper = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		req := requireABCIQuery(t, path, "/cosmos.staking.v1beta1.Query/Validator")
+		require.Equal(t, protoAppendString(nil, 1, valoper), req)
+		return abciResponse(0, protoAppendBytes(nil, 1, protoValidator(valoper, "1000"))), nil
+	}
+	client := NewRPCClient(httpClient)
+	got, err := client.StakingValidator(context.Background(), valoper)
+
+	require.NoError(t, err)
+	require.Equal(t, valoper, got.OperatorAddress)
+	require.Equal(t, "Test Validator", got.Description.Moniker)
+	require.Equal(t, BondStatusBonded, got.BondStatus())
+	require.False(t, got.Jailed)
+	require.Equal(t, 1000.0, got.TokensAmount())
+	require.Equal(t, 1000.0, got.DelegatorSharesAmount())
+	require.Equal(t, 0.05, got.CommissionRate())
+	require.Equal(t, 0.2, got.CommissionMaxRate())
+
+	consaddress, err := got.ConsAddress()
+	require.NoError(t, err)
+	require.Equal(t, "cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g", consaddress)
+}
+
+func TestRPCClient_BondedValidators(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	var httpClient mockHTTPClient
+	httpClient.GetFn = func(ctx context.Context, path url.URL) (*http.Response, error) {
+		req := requireABCIQuery(t, path, "/cosmos.staking.v1beta1.Query/Validators")
+		calls++
+
+		var resp []byte
+		switch calls {
+		case 1:
+			require.Equal(t, encodeValidatorsRequest("BOND_STATUS_BONDED", nil), req)
+			resp = protoAppendBytes(resp, 1, protoValidator("cosmosvaloper1", "1"))
+			resp = protoAppendBytes(resp, 1, protoValidator("cosmosvaloper2", "2"))
+			resp = protoAppendBytes(resp, 2, protoAppendBytes(nil, 1, []byte("next")))
+		case 2:
+			require.Equal(t, encodeValidatorsRequest("BOND_STATUS_BONDED", []byte("next")), req)
+			resp = protoAppendBytes(resp, 1, protoValidator("cosmosvaloper3", "3"))
+		default:
+			t.Fatalf("unexpected call %d", calls)
+		}
+		return abciResponse(0, resp), nil
+	}
+	client := NewRPCClient(httpClient)
+	got, err := client.BondedValidators(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, "cosmosvaloper1", got[0].OperatorAddress)
+	require.Equal(t, "cosmosvaloper3", got[2].OperatorAddress)
+	require.Equal(t, 3.0, got[2].TokensAmount())
+}
@@ -0,0 +1,267 @@
+package cosmos
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/exp/slog"
+)
+
+// streamReadTimeout is how long a BlockStream waits for a message before treating the connection as dead.
+// Blocks are typically produced every few seconds, so a minute without one means the socket or the chain is stuck.
+const streamReadTimeout = time.Minute
+
+// Backoff between reconnect attempts. Doubles after each failed attempt.
+const (
+	minStreamBackoff = time.Second
+	maxStreamBackoff = 30 * time.Second
+)
+
+type StreamMetrics interface {
+	SetStreamConnected(chain string, host url.URL, connected bool)
+	IncStreamReconnect(chain string, host url.URL)
+}
+
+// BlockHandler processes blocks received from a BlockStream.
+type BlockHandler interface {
+	HandleBlock(ctx context.Context, block Block) error
+}
+
+// BlockStream subscribes to new blocks over the CometBFT RPC websocket and passes each block to its handlers.
+// If the connection drops, it reconnects to the next endpoint with exponential backoff.
+// While disconnected, tasks fall back to polling. See Connected.
+type BlockStream struct {
+	chainID string
+	dialer  *websocket.Dialer
+	metrics StreamMetrics
+	urls    []url.URL
+	headers map[url.URL]http.Header
+	tls     map[url.URL]*tls.Config
+	proxies map[url.URL]*url.URL
+
+	mu       sync.Mutex
+	handlers []BlockHandler
+
+	connected atomic.Bool
+}
+
+// NewBlockStream returns a stream for the chain given the RPC endpoints, e.g. http://localhost:26657.
+func NewBlockStream(metrics StreamMetrics, urls []url.URL, chain Chain) *BlockStream {
+	return &BlockStream{
+		chainID: chain.ChainID,
+		dialer:  websocket.DefaultDialer,
+		metrics: metrics,
+		urls:    urls,
+	}
+}
+
+// SetHeader adds the header to the websocket handshake with the host, e.g. an API key. Must be called before Run.
+func (s *BlockStream) SetHeader(host url.URL, header http.Header) {
+	if s.headers == nil {
+		s.headers = make(map[url.URL]http.Header)
+	}
+	s.headers[host] = header.Clone()
+}
+
+// SetTLSConfig configures TLS for the websocket connection to the host, e.g. for a private CA.
+// Must be called before Run.
+func (s *BlockStream) SetTLSConfig(host url.URL, cfg *tls.Config) {
+	if s.tls == nil {
+		s.tls = make(map[url.URL]*tls.Config)
+	}
+	s.tls[host] = cfg
+}
+
+// SetProxy connects to the host through the proxy, e.g. http://proxy:3128 or socks5://proxy:1080.
+// Must be called before Run.
+func (s *BlockStream) SetProxy(host url.URL, proxy *url.URL) {
+	if s.proxies == nil {
+		s.proxies = make(map[url.URL]*url.URL)
+	}
+	s.proxies[host] = proxy
+}
+
+// Subscribe adds a handler that is called for new blocks. Handlers are called sequentially, outside the loop
+// reading from the websocket. If handlers fall behind, only the latest block is passed to them, so handlers
+// must catch up on skipped heights themselves.
+func (s *BlockStream) Subscribe(handler BlockHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+// Connected returns true while the stream is subscribed to new blocks. A nil stream is never connected.
+func (s *BlockStream) Connected() bool {
+	return s != nil && s.connected.Load()
+}
+
+// Run streams blocks until the context is cancelled, reconnecting as needed.
+func (s *BlockStream) Run(ctx context.Context) {
+	if len(s.urls) == 0 {
+		return
+	}
+
+	pending := make(chan Block, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for block := range pending {
+			s.handle(ctx, block)
+		}
+	}()
+	defer func() {
+		close(pending)
+		<-done
+	}()
+
+	backoff := minStreamBackoff
+	for i := 0; ; i++ {
+		host := s.urls[i%len(s.urls)]
+		if i > 0 {
+			s.metrics.IncStreamReconnect(s.chainID, host)
+		}
+
+		subscribed, err := s.stream(ctx, host, pending)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			backoff = minStreamBackoff
+		}
+		slog.Warn("Block stream disconnected, falling back to polling",
+			"chain", s.chainID, "host", host.Hostname(), "retry", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxStreamBackoff)
+	}
+}
+
+// stream subscribes to new blocks from a single endpoint until the connection fails. Blocks are offered to pending.
+// Returns true if the subscription succeeded before failing.
+func (s *BlockStream) stream(ctx context.Context, host url.URL, pending chan Block) (bool, error) {
+	wsURL := websocketURL(host)
+	dialer := *s.dialer
+	if cfg := s.tls[host]; cfg != nil {
+		dialer.TLSClientConfig = cfg
+	}
+	if proxy := s.proxies[host]; proxy != nil {
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+	conn, _, err := dialer.DialContext(ctx, wsURL.String(), s.headers[host])
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	// Closing the connection unblocks a pending read.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	defer func() {
+		s.connected.Store(false)
+		s.metrics.SetStreamConnected(s.chainID, host, false)
+	}()
+
+	err = conn.WriteJSON(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "subscribe",
+		"params":  map[string]string{"query": "tm.event='NewBlock'"},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var subscribed bool
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(streamReadTimeout)); err != nil {
+			return subscribed, err
+		}
+		var msg struct {
+			Result struct {
+				Data struct {
+					Type  string          `json:"type"`
+					Value json.RawMessage `json:"value"`
+				} `json:"data"`
+			} `json:"result"`
+			Error *rpcError `json:"error"`
+		}
+		if err = conn.ReadJSON(&msg); err != nil {
+			return subscribed, err
+		}
+		if msg.Error != nil {
+			return subscribed, *msg.Error
+		}
+
+		switch msg.Result.Data.Type {
+		case "":
+			// The subscription is acknowledged with an empty result.
+			if !subscribed {
+				subscribed = true
+				s.connected.Store(true)
+				s.metrics.SetStreamConnected(s.chainID, host, true)
+				slog.Info("Block stream connected", "chain", s.chainID, "host", host.Hostname())
+			}
+		case "tendermint/event/NewBlock":
+			// The event value has the same shape as the RPC /block response.
+			var block Block
+			if err = json.Unmarshal(msg.Result.Data.Value, &block); err != nil {
+				return subscribed, fmt.Errorf("malformed block event: %w", err)
+			}
+			if err = normalizeRPCBlock(&block); err != nil {
+				return subscribed, err
+			}
+			offerBlock(pending, block)
+		default:
+			return subscribed, errors.New("unexpected event type " + msg.Result.Data.Type)
+		}
+	}
+}
+
+// offerBlock replaces a block not yet taken by the handlers with the newer block, so reading from the websocket
+// never waits for slow handlers. Must only be called by a single goroutine.
+func offerBlock(pending chan Block, block Block) {
+	select {
+	case <-pending:
+	default:
+	}
+	pending <- block
+}
+
+func (s *BlockStream) handle(ctx context.Context, block Block) {
+	s.mu.Lock()
+	handlers := s.handlers
+	s.mu.Unlock()
+
+	for _, h := range handlers {
+		if err := h.HandleBlock(ctx, block); err != nil {
+			slog.Warn("Failed to handle streamed block", "chain", s.chainID, "height", block.Block.Header.Height, "error", err)
+		}
+	}
+}
+
+// websocketURL returns the CometBFT websocket endpoint for an RPC url.
+func websocketURL(u url.URL) url.URL {
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	// The RPC may be served under a path prefix, e.g. behind a reverse proxy.
+	u.Path = path.Join("/", u.Path, "websocket")
+	return u
+}
@@ -0,0 +1,212 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStreamMetrics struct {
+	mu         sync.Mutex
+	Connected  []bool
+	Reconnects int
+}
+
+func (m *mockStreamMetrics) SetStreamConnected(chain string, host url.URL, connected bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Connected = append(m.Connected, connected)
+}
+
+func (m *mockStreamMetrics) IncStreamReconnect(chain string, host url.URL) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Reconnects++
+}
+
+type mockBlockHandler struct {
+	HandleFn func(ctx context.Context, block Block) error
+}
+
+func (m mockBlockHandler) HandleBlock(ctx context.Context, block Block) error {
+	return m.HandleFn(ctx, block)
+}
+
+// newStreamServer returns a CometBFT websocket server that acknowledges the subscription, sends the fixture
+// block as a NewBlock event, then closes the connection.
+func newStreamServer(t *testing.T) *httptest.Server {
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(rpcBlockFixture, &envelope))
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/websocket", r.URL.Path)
+		conn, err := new(websocket.Upgrader).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				Query string `json:"query"`
+			} `json:"params"`
+		}
+		require.NoError(t, conn.ReadJSON(&req))
+		require.Equal(t, "subscribe", req.Method)
+		require.Equal(t, "tm.event='NewBlock'", req.Params.Query)
+
+		require.NoError(t, conn.WriteJSON(map[string]any{"jsonrpc": "2.0", "id": 1, "result": map[string]any{}}))
+		require.NoError(t, conn.WriteJSON(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result": map[string]any{
+				"query": "tm.event='NewBlock'",
+				"data": map[string]any{
+					"type":  "tendermint/event/NewBlock",
+					"value": envelope.Result,
+				},
+			},
+		}))
+	}))
+}
+
+func TestBlockStream_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		server := newStreamServer(t)
+		defer server.Close()
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		var metrics mockStreamMetrics
+		stream := NewBlockStream(&metrics, []url.URL{*u}, Chain{ChainID: "cosmoshub-4"})
+		require.False(t, stream.Connected())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var got Block
+		stream.Subscribe(mockBlockHandler{HandleFn: func(ctx context.Context, block Block) error {
+			got = block
+			cancel()
+			return nil
+		}})
+
+		stream.Run(ctx)
+
+		require.Equal(t, "15312655", got.Block.Header.Height)
+		require.Equal(t, "1o7sDS6CSPHsZM21he22HspDK9g=", got.Block.LastCommit.Signatures[0].ValidatorAddress)
+		require.False(t, stream.Connected())
+		require.Equal(t, []bool{true, false}, metrics.Connected)
+		require.Zero(t, metrics.Reconnects)
+	})
+
+	t.Run("reconnects to next endpoint", func(t *testing.T) {
+		server := newStreamServer(t)
+		defer server.Close()
+		good, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		bad, err := url.Parse("http://127.0.0.1:1")
+		require.NoError(t, err)
+
+		var metrics mockStreamMetrics
+		stream := NewBlockStream(&metrics, []url.URL{*bad, *good}, Chain{ChainID: "cosmoshub-4"})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var handled bool
+		stream.Subscribe(mockBlockHandler{HandleFn: func(ctx context.Context, block Block) error {
+			handled = true
+			cancel()
+			return nil
+		}})
+
+		stream.Run(ctx)
+
+		require.True(t, handled)
+		require.Equal(t, 1, metrics.Reconnects)
+	})
+
+	t.Run("sends headers", func(t *testing.T) {
+		got := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case got <- r.Header.Get("X-Api-Key"):
+			default:
+			}
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		var metrics mockStreamMetrics
+		stream := NewBlockStream(&metrics, []url.URL{*u}, Chain{ChainID: "cosmoshub-4"})
+		stream.SetHeader(*u, http.Header{"X-Api-Key": []string{"secret"}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var header string
+		go func() {
+			defer cancel()
+			header = <-got
+		}()
+		stream.Run(ctx)
+
+		require.Equal(t, "secret", header)
+	})
+
+	t.Run("no endpoints", func(t *testing.T) {
+		stream := NewBlockStream(nil, nil, Chain{})
+		stream.Run(context.Background())
+	})
+}
+
+func TestBlockStream_Connected(t *testing.T) {
+	t.Parallel()
+
+	var stream *BlockStream
+	require.False(t, stream.Connected())
+}
+
+func TestOfferBlock(t *testing.T) {
+	t.Parallel()
+
+	pending := make(chan Block, 1)
+	for _, height := range []string{"1", "2", "3"} {
+		var block Block
+		block.Block.Header.Height = height
+		offerBlock(pending, block)
+	}
+
+	got := <-pending
+	require.Equal(t, "3", got.Block.Header.Height)
+	require.Empty(t, pending)
+}
+
+func TestWebsocketURL(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		In, Want string
+	}{
+		{"http://localhost:26657", "ws://localhost:26657/websocket"},
+		{"https://rpc.example.com", "wss://rpc.example.com/websocket"},
+		{"https://example.com/cosmoshub/rpc/", "wss://example.com/cosmoshub/rpc/websocket"},
+	} {
+		u, err := url.Parse(tt.In)
+		require.NoError(t, err)
+		got := websocketURL(*u)
+		require.Equal(t, tt.Want, got.String())
+	}
+}
@@ -0,0 +1,107 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+type StakingMetrics interface {
+	SetValBondStatus(chain, valoper string, status BondStatus)
+	SetValTokens(chain, valoper string, tokens float64)
+	SetValDelegatorShares(chain, valoper string, shares float64)
+	SetValCommission(chain, valoper string, rate, maxRate float64)
+	SetValRank(chain, valoper string, rank float64)
+}
+
+type StakingClient interface {
+	StakingValidator(ctx context.Context, valoper string) (StakingValidator, error)
+	BondedValidators(ctx context.Context) ([]StakingValidator, error)
+}
+
+// StakingTask queries the staking module for all validators on a chain with an operator address.
+// It records:
+// - the bond status (bonded, unbonding, unbonded)
+// - the bonded tokens and delegator shares
+// - the commission rate and max rate
+// - the rank by voting power within the active set
+type StakingTask struct {
+	chainID  string
+	client   StakingClient
+	metrics  StakingMetrics
+	valopers []string
+}
+
+func NewStakingTask(metrics StakingMetrics, client StakingClient, chain Chain) StakingTask {
+	var valopers []string
+	for _, val := range chain.Validators {
+		if val.Valoper != "" {
+			valopers = append(valopers, val.Valoper)
+		}
+	}
+	return StakingTask{
+		chainID:  chain.ChainID,
+		client:   client,
+		metrics:  metrics,
+		valopers: valopers,
+	}
+}
+
+func (task StakingTask) Group() string { return task.chainID }
+func (task StakingTask) ID() string    { return "staking" }
+
+// Interval is hardcoded to a longer duration because staking records change less frequently than blocks and
+// the active set response is large. Longer duration minimizes API calls to prevent hitting rate limits.
+func (task StakingTask) Interval() time.Duration { return time.Minute }
+
+// Run records staking metrics for all validators.
+func (task StakingTask) Run(ctx context.Context) error {
+	if len(task.valopers) == 0 {
+		return nil
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	bonded, err := task.client.BondedValidators(cctx)
+	if err != nil {
+		return err
+	}
+	ranks := rankByTokens(bonded)
+
+	var errs []error
+	for _, valoper := range task.valopers {
+		errs = append(errs, task.processValidator(ctx, valoper))
+		// Validators outside the active set have rank 0.
+		task.metrics.SetValRank(task.chainID, valoper, float64(ranks[valoper]))
+	}
+	return errors.Join(errs...)
+}
+
+func (task StakingTask) processValidator(ctx context.Context, valoper string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	val, err := task.client.StakingValidator(ctx, valoper)
+	if err != nil {
+		return err
+	}
+	task.metrics.SetValBondStatus(task.chainID, valoper, val.BondStatus())
+	task.metrics.SetValTokens(task.chainID, valoper, val.TokensAmount())
+	task.metrics.SetValDelegatorShares(task.chainID, valoper, val.DelegatorSharesAmount())
+	task.metrics.SetValCommission(task.chainID, valoper, val.CommissionRate(), val.CommissionMaxRate())
+	return nil
+}
+
+// rankByTokens returns the 1-based rank of each validator's operator address by descending tokens.
+func rankByTokens(vals []StakingValidator) map[string]int {
+	sorted := make([]StakingValidator, len(vals))
+	copy(sorted, vals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TokensAmount() > sorted[j].TokensAmount()
+	})
+	ranks := make(map[string]int, len(sorted))
+	for i, val := range sorted {
+		ranks[val.OperatorAddress] = i + 1
+	}
+	return ranks
+}
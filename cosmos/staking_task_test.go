@@ -0,0 +1,177 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockStakingClient struct {
+	StubBonded     []StakingValidator
+	StubValidators map[string]StakingValidator
+}
+
+func (m *mockStakingClient) StakingValidator(ctx context.Context, valoper string) (StakingValidator, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	val, ok := m.StubValidators[valoper]
+	if !ok {
+		return StakingValidator{}, errors.New("not found")
+	}
+	return val, nil
+}
+
+func (m *mockStakingClient) BondedValidators(ctx context.Context) ([]StakingValidator, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	return m.StubBonded, nil
+}
+
+type mockStakingMetrics struct {
+	GotChain      string
+	BondStatus    map[string]BondStatus
+	Tokens        map[string]float64
+	Shares        map[string]float64
+	Commission    map[string]float64
+	MaxCommission map[string]float64
+	Rank          map[string]float64
+}
+
+func newMockStakingMetrics() *mockStakingMetrics {
+	return &mockStakingMetrics{
+		BondStatus:    make(map[string]BondStatus),
+		Tokens:        make(map[string]float64),
+		Shares:        make(map[string]float64),
+		Commission:    make(map[string]float64),
+		MaxCommission: make(map[string]float64),
+		Rank:          make(map[string]float64),
+	}
+}
+
+func (m *mockStakingMetrics) SetValBondStatus(chain, valoper string, status BondStatus) {
+	m.GotChain = chain
+	m.BondStatus[valoper] = status
+}
+
+func (m *mockStakingMetrics) SetValTokens(chain, valoper string, tokens float64) {
+	m.GotChain = chain
+	m.Tokens[valoper] = tokens
+}
+
+func (m *mockStakingMetrics) SetValDelegatorShares(chain, valoper string, shares float64) {
+	m.GotChain = chain
+	m.Shares[valoper] = shares
+}
+
+func (m *mockStakingMetrics) SetValCommission(chain, valoper string, rate, maxRate float64) {
+	m.GotChain = chain
+	m.Commission[valoper] = rate
+	m.MaxCommission[valoper] = maxRate
+}
+
+func (m *mockStakingMetrics) SetValRank(chain, valoper string, rank float64) {
+	m.GotChain = chain
+	m.Rank[valoper] = rank
+}
+
+func TestStakingTask_Interval(t *testing.T) {
+	t.Parallel()
+
+	task := NewStakingTask(nil, nil, Chain{Interval: time.Second})
+
+	require.Equal(t, time.Minute, task.Interval())
+}
+
+func TestStakingTask_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newVal := func(valoper, status, tokens string) StakingValidator {
+		var val StakingValidator
+		val.OperatorAddress = valoper
+		val.Status = status
+		val.Tokens = tokens
+		val.DelegatorShares = tokens + ".5"
+		val.Commission.CommissionRates.Rate = "0.05"
+		val.Commission.CommissionRates.MaxRate = "0.2"
+		return val
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		chain := Chain{
+			ChainID: "cosmoshub-4",
+			Validators: []Validator{
+				{ConsAddress: "cosmosvalcons1", Valoper: "valoper1"},
+				{ConsAddress: "cosmosvalcons2", Valoper: "valoper2"},
+				{ConsAddress: "cosmosvalcons3"},
+			},
+		}
+
+		val1 := newVal("valoper1", "BOND_STATUS_BONDED", "100")
+		val2 := newVal("valoper2", "BOND_STATUS_UNBONDING", "10")
+		client := &mockStakingClient{
+			StubBonded: []StakingValidator{
+				newVal("other1", "BOND_STATUS_BONDED", "50"),
+				val1,
+				newVal("other2", "BOND_STATUS_BONDED", "200"),
+			},
+			StubValidators: map[string]StakingValidator{"valoper1": val1, "valoper2": val2},
+		}
+		metrics := newMockStakingMetrics()
+
+		task := NewStakingTask(metrics, client, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, "cosmoshub-4", metrics.GotChain)
+
+		require.Equal(t, BondStatusBonded, metrics.BondStatus["valoper1"])
+		require.Equal(t, 100.0, metrics.Tokens["valoper1"])
+		require.Equal(t, 100.5, metrics.Shares["valoper1"])
+		require.Equal(t, 0.05, metrics.Commission["valoper1"])
+		require.Equal(t, 0.2, metrics.MaxCommission["valoper1"])
+		require.Equal(t, 2.0, metrics.Rank["valoper1"])
+
+		require.Equal(t, BondStatusUnbonding, metrics.BondStatus["valoper2"])
+		require.Equal(t, 0.0, metrics.Rank["valoper2"])
+
+		require.Len(t, metrics.BondStatus, 2)
+	})
+
+	t.Run("no operator addresses", func(t *testing.T) {
+		chain := Chain{
+			ChainID:    "cosmoshub-4",
+			Validators: []Validator{{ConsAddress: "cosmosvalcons1"}},
+		}
+
+		task := NewStakingTask(nil, nil, chain)
+		err := task.Run(ctx)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		chain := Chain{
+			ChainID:    "cosmoshub-4",
+			Validators: []Validator{{Valoper: "valoper1"}, {Valoper: "valoper2"}},
+		}
+		client := &mockStakingClient{
+			StubValidators: map[string]StakingValidator{"valoper2": newVal("valoper2", "BOND_STATUS_UNBONDED", "1")},
+		}
+		metrics := newMockStakingMetrics()
+
+		task := NewStakingTask(metrics, client, chain)
+		err := task.Run(ctx)
+
+		require.EqualError(t, err, "not found")
+		require.Equal(t, BondStatusUnbonded, metrics.BondStatus["valoper2"])
+	})
+}
@@ -0,0 +1,87 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type UpgradeMetrics interface {
+	SetUpgradePlan(chain, name string, height, estimatedSeconds float64)
+	ClearUpgradePlan(chain string)
+}
+
+type UpgradeClient interface {
+	UpgradePlan(ctx context.Context) (*UpgradePlan, error)
+}
+
+// BlockIntervals provides the recent average interval between blocks, e.g. BlockHeightTask.
+type BlockIntervals interface {
+	AvgBlockInterval() (time.Duration, bool)
+}
+
+// UpgradeTask queries the upgrade module for a scheduled software upgrade and records metrics.
+// It records:
+// - the upgrade name and height
+// - the estimated seconds until the upgrade based on the recent average block time
+type UpgradeTask struct {
+	blocks    Client
+	chainID   string
+	client    UpgradeClient
+	interval  time.Duration
+	intervals BlockIntervals
+	metrics   UpgradeMetrics
+}
+
+func NewUpgradeTask(metrics UpgradeMetrics, client UpgradeClient, blocks Client, intervals BlockIntervals, chain Chain) UpgradeTask {
+	return UpgradeTask{
+		blocks:    blocks,
+		chainID:   chain.ChainID,
+		client:    client,
+		interval:  intervalOrDefault(chain.Interval),
+		intervals: intervals,
+		metrics:   metrics,
+	}
+}
+
+func (task UpgradeTask) Group() string { return task.chainID }
+func (task UpgradeTask) ID() string    { return "upgrade" }
+
+func (task UpgradeTask) Interval() time.Duration { return task.interval }
+
+// Run records metrics for the current upgrade plan. Metrics are cleared if no upgrade is scheduled.
+// The estimate reuses the average block interval observed by the block height task, so no historical
+// blocks are fetched.
+func (task UpgradeTask) Run(ctx context.Context) error {
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	plan, err := task.client.UpgradePlan(cctx)
+	if err != nil {
+		return err
+	}
+	if plan == nil || plan.HeightValue() <= 0 {
+		task.metrics.ClearUpgradePlan(task.chainID)
+		return nil
+	}
+
+	avgBlockTime, ok := task.intervals.AvgBlockInterval()
+	if !ok {
+		// Not enough blocks observed yet, e.g. right after startup.
+		return nil
+	}
+
+	cctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	latest, err := task.blocks.LatestBlock(cctx)
+	if err != nil {
+		return err
+	}
+	latestHeight, err := latest.Height()
+	if err != nil {
+		return fmt.Errorf("parse block height: %w", err)
+	}
+
+	estimate := max(float64(plan.HeightValue()-latestHeight)*avgBlockTime.Seconds(), 0)
+	task.metrics.SetUpgradePlan(task.chainID, plan.Name, float64(plan.HeightValue()), estimate)
+	return nil
+}
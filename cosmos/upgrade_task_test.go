@@ -0,0 +1,133 @@
+package cosmos
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockUpgradeClient struct {
+	StubPlan *UpgradePlan
+}
+
+func (m mockUpgradeClient) UpgradePlan(ctx context.Context) (*UpgradePlan, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	return m.StubPlan, nil
+}
+
+type mockUpgradeBlocks struct {
+	Latest int64
+}
+
+func (m *mockUpgradeBlocks) LatestBlock(ctx context.Context) (Block, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	var blk Block
+	blk.Block.Header.Height = strconv.FormatInt(m.Latest, 10)
+	return blk, nil
+}
+
+type mockBlockIntervals struct {
+	StubAvg time.Duration
+}
+
+func (m mockBlockIntervals) AvgBlockInterval() (time.Duration, bool) {
+	return m.StubAvg, m.StubAvg > 0
+}
+
+type mockUpgradeMetrics struct {
+	GotChain    string
+	GotName     string
+	GotHeight   float64
+	GotEstimate float64
+	Cleared     bool
+}
+
+func (m *mockUpgradeMetrics) SetUpgradePlan(chain, name string, height, estimatedSeconds float64) {
+	m.GotChain = chain
+	m.GotName = name
+	m.GotHeight = height
+	m.GotEstimate = estimatedSeconds
+}
+
+func (m *mockUpgradeMetrics) ClearUpgradePlan(chain string) {
+	m.GotChain = chain
+	m.Cleared = true
+}
+
+func TestUpgradeTask_Interval(t *testing.T) {
+	t.Parallel()
+
+	task := NewUpgradeTask(nil, nil, nil, nil, Chain{Interval: time.Second})
+	require.Equal(t, time.Second, task.Interval())
+
+	task = NewUpgradeTask(nil, nil, nil, nil, Chain{})
+	require.Equal(t, defaultInterval, task.Interval())
+}
+
+func TestUpgradeTask_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	chain := Chain{ChainID: "cosmoshub-4"}
+
+	t.Run("happy path", func(t *testing.T) {
+		client := mockUpgradeClient{StubPlan: &UpgradePlan{Name: "v15", Height: "1500"}}
+		blocks := &mockUpgradeBlocks{Latest: 1000}
+		var metrics mockUpgradeMetrics
+
+		task := NewUpgradeTask(&metrics, client, blocks, mockBlockIntervals{StubAvg: 6 * time.Second}, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, "cosmoshub-4", metrics.GotChain)
+		require.Equal(t, "v15", metrics.GotName)
+		require.Equal(t, 1500.0, metrics.GotHeight)
+		require.InDelta(t, 3000.0, metrics.GotEstimate, 0.001)
+		require.False(t, metrics.Cleared)
+	})
+
+	t.Run("upgrade height passed", func(t *testing.T) {
+		client := mockUpgradeClient{StubPlan: &UpgradePlan{Name: "v15", Height: "1500"}}
+		blocks := &mockUpgradeBlocks{Latest: 1501}
+		var metrics mockUpgradeMetrics
+
+		task := NewUpgradeTask(&metrics, client, blocks, mockBlockIntervals{StubAvg: 6 * time.Second}, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, "v15", metrics.GotName)
+		require.Zero(t, metrics.GotEstimate)
+	})
+
+	t.Run("no block interval yet", func(t *testing.T) {
+		client := mockUpgradeClient{StubPlan: &UpgradePlan{Name: "v15", Height: "1500"}}
+		var metrics mockUpgradeMetrics
+
+		task := NewUpgradeTask(&metrics, client, nil, mockBlockIntervals{}, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Empty(t, metrics.GotName)
+		require.False(t, metrics.Cleared)
+	})
+
+	t.Run("no plan", func(t *testing.T) {
+		var metrics mockUpgradeMetrics
+
+		task := NewUpgradeTask(&metrics, mockUpgradeClient{}, nil, nil, chain)
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.True(t, metrics.Cleared)
+		require.Equal(t, "cosmoshub-4", metrics.GotChain)
+	})
+}
@@ -2,6 +2,7 @@ package cosmos
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -10,13 +11,40 @@ type ValParamsClient interface {
 }
 
 type ValParamsMetrics interface {
-	SetValSlashingParams(chain string, window float64)
+	SetValSlashingParams(chain string, params SlashingParams)
+}
+
+// ValParams holds the latest params fetched by a ValParamsTask, so other tasks for the same chain
+// can use them without additional API calls.
+type ValParams struct {
+	mu       sync.RWMutex
+	slashing *SlashingParams
+}
+
+// Slashing returns the latest slashing params. Returns false if params have not been fetched yet.
+func (p *ValParams) Slashing() (SlashingParams, bool) {
+	if p == nil {
+		return SlashingParams{}, false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.slashing == nil {
+		return SlashingParams{}, false
+	}
+	return *p.slashing, true
+}
+
+func (p *ValParams) setSlashing(params SlashingParams) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.slashing = &params
 }
 
 type ValParamsTask struct {
 	chainID string
 	client  ValParamsClient
 	metrics ValParamsMetrics
+	params  *ValParams
 }
 
 func NewValParamsTask(metrics ValParamsMetrics, client ValParamsClient, chain Chain) ValParamsTask {
@@ -24,6 +52,7 @@ func NewValParamsTask(metrics ValParamsMetrics, client ValParamsClient, chain Ch
 		chainID: chain.ChainID,
 		client:  client,
 		metrics: metrics,
+		params:  new(ValParams),
 	}
 }
 
@@ -34,6 +63,9 @@ func (p ValParamsTask) ID() string    { return "params" }
 // They require a gov proposal. Additionally, longer duration minimizes API calls to prevent hitting rate limits.
 func (p ValParamsTask) Interval() time.Duration { return 5 * time.Minute }
 
+// Params returns the latest params fetched by the task. Safe to share amongst tasks.
+func (p ValParamsTask) Params() *ValParams { return p.params }
+
 func (p ValParamsTask) Run(ctx context.Context) error {
 	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
 	defer cancel()
@@ -41,6 +73,7 @@ func (p ValParamsTask) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	p.metrics.SetValSlashingParams(p.chainID, slash.SignedBlocksWindow())
+	p.params.setSlashing(slash)
+	p.metrics.SetValSlashingParams(p.chainID, slash)
 	return nil
 }
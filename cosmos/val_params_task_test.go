@@ -10,12 +10,12 @@ import (
 
 type mockParamsMetrics struct {
 	GotSlashingChain  string
-	GotSlashingWindow float64
+	GotSlashingParams SlashingParams
 }
 
-func (m *mockParamsMetrics) SetValSlashingParams(chain string, window float64) {
+func (m *mockParamsMetrics) SetValSlashingParams(chain string, params SlashingParams) {
 	m.GotSlashingChain = chain
-	m.GotSlashingWindow = window
+	m.GotSlashingParams = params
 }
 
 type mockParamsClient struct {
@@ -45,9 +45,16 @@ func TestValParamsTask_Run(t *testing.T) {
 
 	task := NewValParamsTask(&metrics, client, Chain{ChainID: "cosmoshub-4"})
 
+	_, ok := task.Params().Slashing()
+	require.False(t, ok)
+
 	err := task.Run(context.Background())
 	require.NoError(t, err)
 
 	require.Equal(t, "cosmoshub-4", metrics.GotSlashingChain)
-	require.Equal(t, float64(10000), metrics.GotSlashingWindow)
+	require.Equal(t, float64(10000), metrics.GotSlashingParams.SignedBlocksWindow())
+
+	got, ok := task.Params().Slashing()
+	require.True(t, ok)
+	require.Equal(t, client.StubSlashingParams, got)
 }
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"golang.org/x/exp/slog"
 )
 
 // JailStatus is the status of a validator.
@@ -21,44 +23,112 @@ const (
 	JailStatusTombstoned
 )
 
+// resolveConsAddressInterval is how often a ValidatorTask with an operator address re-resolves its
+// consensus address. Re-resolving detects consensus key rotations.
+const resolveConsAddressInterval = 5 * time.Minute
+
+// resolveConsAddressRetry is how long a ValidatorTask waits to resolve its consensus address after a failure.
+const resolveConsAddressRetry = time.Minute
+
+// maxTrackedHeights limits how many blocks a ValidatorTask processes in a single run when tracking heights.
+// If the task falls further behind, it skips to the most recent heights.
+const maxTrackedHeights = 100
+
 type ValidatorMetrics interface {
 	IncValSignedBlocks(chain, consaddress string)
+	IncValMissedBlocks(chain, consaddress string)
+	SetValConsecutiveMissedBlocks(chain, consaddress string, missed float64)
 	SetValJailStatus(chain, consaddress string, status JailStatus)
 	SetValSignedBlock(chain, consaddress string, height float64)
 	SetValMissedBlocks(chain, consaddress string, missed float64)
+	SetValMissedBlocksUntilJail(chain, consaddress string, blocks float64)
+	SetValConsAddressMismatch(chain, valoper string, mismatch bool)
+	// DeleteValMetrics deletes the metrics of a consensus address that is no longer used, e.g. after a key rotation.
+	DeleteValMetrics(chain, consaddress string)
 }
 
 type ValidatorClient interface {
-	LatestBlock(ctx context.Context) (Block, error)
 	SigningInfo(ctx context.Context, consaddress string) (SigningInfo, error)
+	StakingValidator(ctx context.Context, valoper string) (StakingValidator, error)
 }
 
 // ValidatorTask queries the Cosmos REST (aka LCD) API for data and records metrics specific to a validator.
 // It records:
 // - whether the validator is jailed or tombstoned
-// - the number of blocks signed by the validator
-// - the number of validator missed blocks
+// - the number of blocks signed and missed by the validator
+// - the number of consecutive blocks missed by the validator
+// - the number of validator missed blocks within the slashing window
+// - the number of blocks the validator may miss before being jailed
+//
+// If the validator has an operator address, the consensus address is resolved from the staking module.
+// A configured consensus address that does not match the resolved address is logged and recorded.
 type ValidatorTask struct {
-	chainID     string
-	client      ValidatorClient
-	consaddress string
-	interval    time.Duration
-	metrics     ValidatorMetrics
+	blocks       BlockClient
+	chainID      string
+	client       ValidatorClient
+	consaddress  string
+	interval     time.Duration
+	metrics      ValidatorMetrics
+	params       *ValParams
+	stream       *BlockStream
+	trackHeights bool
+	valoper      string
+
+	// Pointer because the task is passed by value but must remember progress between runs.
+	state *validatorState
+}
+
+type validatorState struct {
+	mu                sync.Mutex
+	lastHeight        int64
+	consecutiveMisses int
+
+	// A validator outside the active set cannot sign blocks, so its blocks are not counted as missed.
+	// Jailed is from the signing info. Unbonded is from the staking record, so only known with an operator address.
+	jailed   bool
+	unbonded bool
+
+	resolvedAddress string
+	// resolveAt is when to next resolve the consensus address. resolveErr is the last error resolving it.
+	resolveAt  time.Time
+	resolveErr error
+	// usedAddress is the consensus address metrics were last recorded under.
+	usedAddress string
 }
 
 func (task ValidatorTask) Group() string { return task.chainID }
-func (task ValidatorTask) ID() string    { return task.consaddress }
 
-func BuildValidatorTasks(metrics ValidatorMetrics, client ValidatorClient, chain Chain) []ValidatorTask {
+func (task ValidatorTask) ID() string {
+	if task.consaddress == "" {
+		return task.valoper
+	}
+	return task.consaddress
+}
+
+// BuildValidatorTasks returns a task per validator. Blocks should be shared amongst all tasks for the chain,
+// typically a BlockCache, to avoid fetching the same block for every validator.
+// Params are typically from a ValParamsTask for the same chain. If nil, metrics derived from params are not recorded.
+// If stream is not nil, each task subscribes to it and only polls for blocks while the stream is disconnected.
+func BuildValidatorTasks(metrics ValidatorMetrics, client ValidatorClient, blocks BlockClient, params *ValParams, stream *BlockStream, chain Chain) []ValidatorTask {
 	var tasks []ValidatorTask
 	for _, val := range chain.Validators {
-		tasks = append(tasks, ValidatorTask{
-			chainID:     chain.ChainID,
-			client:      client,
-			consaddress: val.ConsAddress,
-			interval:    intervalOrDefault(chain.Interval),
-			metrics:     metrics,
-		})
+		task := ValidatorTask{
+			blocks:       blocks,
+			chainID:      chain.ChainID,
+			client:       client,
+			consaddress:  val.ConsAddress,
+			interval:     intervalOrDefault(chain.Interval),
+			metrics:      metrics,
+			params:       params,
+			stream:       stream,
+			trackHeights: chain.TrackHeights,
+			valoper:      val.Valoper,
+			state:        new(validatorState),
+		}
+		if stream != nil {
+			stream.Subscribe(task)
+		}
+		tasks = append(tasks, task)
 	}
 	return tasks
 }
@@ -67,50 +137,223 @@ func (task ValidatorTask) Interval() time.Duration { return task.interval }
 
 // Run executes the task gathering a variety of metrics for cosmos validators.
 func (task ValidatorTask) Run(ctx context.Context) error {
-	return errors.Join(
-		task.processSigningStatus(ctx),
-		task.processSignedBlocks(ctx),
-	)
+	consaddress, err := task.consAddress(ctx)
+	if err != nil {
+		return err
+	}
+	err = task.processSigningStatus(ctx, consaddress)
+	// While streaming, blocks are processed by HandleBlock as they arrive.
+	if task.stream.Connected() {
+		return err
+	}
+	return errors.Join(err, task.processSignedBlocks(ctx, consaddress))
 }
 
-func (task ValidatorTask) processSignedBlocks(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+// HandleBlock records signed and missed blocks for a block received from a BlockStream.
+func (task ValidatorTask) HandleBlock(ctx context.Context, block Block) error {
+	consaddress, err := task.consAddress(ctx)
+	if err != nil {
+		return err
+	}
+	return task.processSignedBlocksUntil(ctx, consaddress, block)
+}
+
+// consAddress returns the configured consensus address or, if the validator has an operator address,
+// the consensus address resolved from the staking module.
+// If resolving fails, falls back to the previously resolved or configured address and retries after
+// resolveConsAddressRetry. If the address changes, metrics of the previous address are deleted.
+func (task ValidatorTask) consAddress(ctx context.Context) (string, error) {
+	if task.valoper == "" {
+		return task.consaddress, nil
+	}
+
+	task.state.mu.Lock()
+	resolved, resolveAt, resolveErr := task.state.resolvedAddress, task.state.resolveAt, task.state.resolveErr
+	task.state.mu.Unlock()
+
+	fallback := resolved
+	if fallback == "" {
+		fallback = task.consaddress
+	}
+	if time.Now().Before(resolveAt) {
+		if fallback == "" {
+			return "", resolveErr
+		}
+		return task.useAddress(fallback), nil
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
 	defer cancel()
+	val, err := task.client.StakingValidator(cctx, task.valoper)
+	if err == nil {
+		task.state.mu.Lock()
+		task.state.unbonded = val.Jailed || val.BondStatus() != BondStatusBonded
+		task.state.mu.Unlock()
+		resolved, err = val.ConsAddress()
+	}
+	if err != nil {
+		err = fmt.Errorf("resolve consensus address: %w", err)
+		task.state.mu.Lock()
+		task.state.resolveAt = time.Now().Add(resolveConsAddressRetry)
+		task.state.resolveErr = err
+		task.state.mu.Unlock()
+		if fallback == "" {
+			return "", err
+		}
+		slog.Warn("Failed to resolve consensus address", "chain", task.chainID, "valoper", task.valoper, "fallback", fallback, "error", err)
+		return task.useAddress(fallback), nil
+	}
+
+	if task.consaddress != "" {
+		mismatch := task.consaddress != resolved
+		if mismatch {
+			slog.Warn("Configured consensus address does not match the chain; using the chain's address",
+				"chain", task.chainID, "valoper", task.valoper, "configured", task.consaddress, "resolved", resolved)
+		}
+		task.metrics.SetValConsAddressMismatch(task.chainID, task.valoper, mismatch)
+	}
+
+	task.state.mu.Lock()
+	if prev := task.state.resolvedAddress; prev != "" && prev != resolved {
+		slog.Warn("Consensus address changed, likely due to a key rotation",
+			"chain", task.chainID, "valoper", task.valoper, "previous", prev, "resolved", resolved)
+	}
+	task.state.resolvedAddress = resolved
+	task.state.resolveAt = time.Now().Add(resolveConsAddressInterval)
+	task.state.resolveErr = nil
+	task.state.mu.Unlock()
+
+	return task.useAddress(resolved), nil
+}
+
+// useAddress records that metrics are recorded under the consensus address. If it differs from the
+// previous address, the previous address's metrics are deleted so they do not go stale.
+func (task ValidatorTask) useAddress(consaddress string) string {
+	task.state.mu.Lock()
+	defer task.state.mu.Unlock()
+	if prev := task.state.usedAddress; prev != "" && prev != consaddress {
+		task.metrics.DeleteValMetrics(task.chainID, prev)
+		task.state.consecutiveMisses = 0
+	}
+	task.state.usedAddress = consaddress
+	return consaddress
+}
 
-	block, err := task.client.LatestBlock(ctx)
+// processSignedBlocks records signed and missed blocks exactly once per height.
+// By default, only the latest block is processed, so heights between polls are skipped.
+// If tracking heights, every height since the previous run is processed.
+func (task ValidatorTask) processSignedBlocks(ctx context.Context, consaddress string) error {
+	cctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	latest, err := task.blocks.LatestBlock(cctx)
 	if err != nil {
 		return err
 	}
-	_, valHex, err := bech32.DecodeAndConvert(task.consaddress)
+	return task.processSignedBlocksUntil(ctx, consaddress, latest)
+}
+
+// processSignedBlocksUntil records signed and missed blocks for all unprocessed heights up to and including latest.
+func (task ValidatorTask) processSignedBlocksUntil(ctx context.Context, consaddress string, latest Block) error {
+	_, valHex, err := bech32.DecodeAndConvert(consaddress)
 	if err != nil {
 		return err
 	}
 
+	latestHeight, err := latest.Height()
+	if err != nil {
+		return fmt.Errorf("parse block height: %w", err)
+	}
+
+	task.state.mu.Lock()
+	lastHeight := task.state.lastHeight
+	task.state.mu.Unlock()
+	if latestHeight <= lastHeight {
+		return nil
+	}
+	start := latestHeight
+	if task.trackHeights && lastHeight > 0 {
+		start = lastHeight + 1
+		if skipTo := latestHeight - maxTrackedHeights + 1; start < skipTo {
+			slog.Warn("Too far behind tracking heights, skipping blocks",
+				"chain", task.chainID, "address", consaddress, "from", start, "to", skipTo-1)
+			start = skipTo
+		}
+	}
+
+	// Fetch without holding the lock, so streamed blocks and the signing status are not blocked while catching up.
+	// Blocks fetched before an error are still processed.
+	blocks := make([]Block, 0, latestHeight-start+1)
+	var fetchErr error
+	for height := start; height < latestHeight; height++ {
+		block, err := task.blockByHeight(ctx, height)
+		if err != nil {
+			fetchErr = err
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if fetchErr == nil {
+		blocks = append(blocks, latest)
+	}
+
+	task.state.mu.Lock()
+	defer task.state.mu.Unlock()
+	for i, block := range blocks {
+		height := start + int64(i)
+		// A concurrent run or streamed block may have processed the height while fetching.
+		if height <= task.state.lastHeight {
+			continue
+		}
+		if err = task.processBlock(block, consaddress, valHex); err != nil {
+			return err
+		}
+		task.state.lastHeight = height
+		task.metrics.SetValConsecutiveMissedBlocks(task.chainID, consaddress, float64(task.state.consecutiveMisses))
+	}
+
+	return fetchErr
+}
+
+func (task ValidatorTask) blockByHeight(ctx context.Context, height int64) (Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
+	defer cancel()
+	return task.blocks.BlockByHeight(ctx, height)
+}
+
+// processBlock records whether the validator signed the block's last commit.
+// A block is only missed if the validator is known to be in the active set.
+// Caller must hold task.state.mu.
+func (task ValidatorTask) processBlock(block Block, consaddress string, valHex []byte) error {
 	for _, sig := range block.Block.LastCommit.Signatures {
 		sigHex, err := base64.StdEncoding.DecodeString(sig.ValidatorAddress)
 		if err != nil {
 			return err
 		}
 		if bytes.Equal(sigHex, valHex) {
-			task.metrics.IncValSignedBlocks(task.chainID, task.consaddress)
-
 			height, err := strconv.ParseFloat(block.Block.LastCommit.Height, 64)
 			if err != nil {
 				return fmt.Errorf("parse block last commit height: %w", err)
 			}
-			task.metrics.SetValSignedBlock(task.chainID, task.consaddress, height)
-
-			break
+			task.metrics.IncValSignedBlocks(task.chainID, consaddress)
+			task.metrics.SetValSignedBlock(task.chainID, consaddress, height)
+			task.state.consecutiveMisses = 0
+			return nil
 		}
 	}
 
+	if task.state.jailed || task.state.unbonded {
+		task.state.consecutiveMisses = 0
+		return nil
+	}
+	task.metrics.IncValMissedBlocks(task.chainID, consaddress)
+	task.state.consecutiveMisses++
 	return nil
 }
 
-func (task ValidatorTask) processSigningStatus(ctx context.Context) error {
+func (task ValidatorTask) processSigningStatus(ctx context.Context, consaddress string) error {
 	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
 	defer cancel()
-	resp, err := task.client.SigningInfo(ctx, task.consaddress)
+	resp, err := task.client.SigningInfo(ctx, consaddress)
 	if err != nil {
 		return err
 	}
@@ -123,13 +366,20 @@ func (task ValidatorTask) processSigningStatus(ctx context.Context) error {
 	if resp.ValSigningInfo.Tombstoned {
 		status = JailStatusTombstoned
 	}
-	task.metrics.SetValJailStatus(task.chainID, task.consaddress, status)
+	task.metrics.SetValJailStatus(task.chainID, consaddress, status)
+	task.state.mu.Lock()
+	task.state.jailed = status != JailStatusActive
+	task.state.mu.Unlock()
 
 	// Capture missed blocks
 	missed, err := strconv.ParseFloat(resp.ValSigningInfo.MissedBlocksCounter, 64)
 	if err != nil {
 		return fmt.Errorf("parse missed blocks counter: %w", err)
 	}
-	task.metrics.SetValMissedBlocks(task.chainID, task.consaddress, missed)
+	task.metrics.SetValMissedBlocks(task.chainID, consaddress, missed)
+
+	if slashing, ok := task.params.Slashing(); ok {
+		task.metrics.SetValMissedBlocksUntilJail(task.chainID, consaddress, slashing.MissedBlocksUntilJail(missed))
+	}
 	return nil
 }
@@ -3,6 +3,8 @@ package cosmos
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strconv"
 	"testing"
 	"time"
 
@@ -12,8 +14,16 @@ import (
 type mockValRestClient struct {
 	StubBlock Block
 
+	GotHeights      []int64
+	StubBlockHeight map[int64]Block
+	OnBlockByHeight func(height int64)
+
 	SigningInfoAddress string
 	StubSigningInfo    SigningInfo
+
+	StakingValidatorCalls int
+	StubStakingValidator  StakingValidator
+	StubStakingErr        error
 }
 
 func (m *mockValRestClient) LatestBlock(ctx context.Context) (Block, error) {
@@ -24,6 +34,18 @@ func (m *mockValRestClient) LatestBlock(ctx context.Context) (Block, error) {
 	return m.StubBlock, nil
 }
 
+func (m *mockValRestClient) BlockByHeight(ctx context.Context, height int64) (Block, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	m.GotHeights = append(m.GotHeights, height)
+	if m.OnBlockByHeight != nil {
+		m.OnBlockByHeight(height)
+	}
+	return m.StubBlockHeight[height], nil
+}
+
 func (m *mockValRestClient) SigningInfo(ctx context.Context, consaddress string) (SigningInfo, error) {
 	_, ok := ctx.Deadline()
 	if !ok {
@@ -33,6 +55,15 @@ func (m *mockValRestClient) SigningInfo(ctx context.Context, consaddress string)
 	return m.StubSigningInfo, nil
 }
 
+func (m *mockValRestClient) StakingValidator(ctx context.Context, valoper string) (StakingValidator, error) {
+	_, ok := ctx.Deadline()
+	if !ok {
+		panic("expected deadline in context")
+	}
+	m.StakingValidatorCalls++
+	return m.StubStakingValidator, m.StubStakingErr
+}
+
 type mockValMetrics struct {
 	GotChain        string
 	GotAddr         string
@@ -41,6 +72,16 @@ type mockValMetrics struct {
 	GotMissedBlocks float64
 
 	SignedBlockCount int
+	MissedBlockCount int
+
+	GotConsecutiveMisses []float64
+	GotUntilJail         *float64
+	GotMismatch          *bool
+	GotDeleted           []string
+}
+
+func (m *mockValMetrics) DeleteValMetrics(chain, consaddress string) {
+	m.GotDeleted = append(m.GotDeleted, consaddress)
 }
 
 func (m *mockValMetrics) SetValJailStatus(chain, consaddress string, status JailStatus) {
@@ -55,6 +96,29 @@ func (m *mockValMetrics) IncValSignedBlocks(chain, consaddress string) {
 	m.GotAddr = consaddress
 }
 
+func (m *mockValMetrics) IncValMissedBlocks(chain, consaddress string) {
+	m.MissedBlockCount++
+	m.GotChain = chain
+	m.GotAddr = consaddress
+}
+
+func (m *mockValMetrics) SetValMissedBlocksUntilJail(chain, consaddress string, blocks float64) {
+	m.GotChain = chain
+	m.GotAddr = consaddress
+	m.GotUntilJail = &blocks
+}
+
+func (m *mockValMetrics) SetValConsAddressMismatch(chain, valoper string, mismatch bool) {
+	m.GotChain = chain
+	m.GotMismatch = &mismatch
+}
+
+func (m *mockValMetrics) SetValConsecutiveMissedBlocks(chain, consaddress string, missed float64) {
+	m.GotChain = chain
+	m.GotAddr = consaddress
+	m.GotConsecutiveMisses = append(m.GotConsecutiveMisses, missed)
+}
+
 func (m *mockValMetrics) SetValSignedBlock(chain, consaddress string, height float64) {
 	m.GotChain = chain
 	m.GotAddr = consaddress
@@ -72,14 +136,14 @@ func TestValidatorTask_Interval(t *testing.T) {
 
 	chain := Chain{Interval: time.Second, Validators: []Validator{{ConsAddress: "1"}, {ConsAddress: "2"}}}
 
-	tasks := BuildValidatorTasks(nil, nil, chain)
+	tasks := BuildValidatorTasks(nil, nil, nil, nil, nil, chain)
 
 	require.Len(t, tasks, 2)
 	require.Equal(t, time.Second, tasks[0].Interval())
 	require.Equal(t, time.Second, tasks[1].Interval())
 
 	chain = Chain{Validators: []Validator{{ConsAddress: "1"}}}
-	tasks = BuildValidatorTasks(nil, nil, chain)
+	tasks = BuildValidatorTasks(nil, nil, nil, nil, nil, chain)
 
 	require.Len(t, tasks, 1)
 	require.Equal(t, defaultInterval, tasks[0].Interval())
@@ -93,7 +157,7 @@ func TestValidatorTask_Run(t *testing.T) {
 	const addr = `cosmosvalcons164q2kq3q3psj436t9p7swmdlh39rw73wpy6qx6`
 
 	t.Run("zero state", func(t *testing.T) {
-		tasks := BuildValidatorTasks(nil, nil, Chain{})
+		tasks := BuildValidatorTasks(nil, nil, nil, nil, nil, Chain{})
 
 		require.Empty(t, tasks)
 	})
@@ -108,7 +172,8 @@ func TestValidatorTask_Run(t *testing.T) {
 
 		client := new(mockValRestClient)
 		var metrics mockValMetrics
-		tasks := BuildValidatorTasks(&metrics, client, chain)
+		tasks := BuildValidatorTasks(&metrics, client, client, nil, nil, chain)
+		client.StubBlock.Block.Header.Height = "2"
 		client.StubBlock.Block.LastCommit.Height = "1"
 		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
 
@@ -119,6 +184,7 @@ func TestValidatorTask_Run(t *testing.T) {
 
 		require.Zero(t, metrics.SignedBlockCount)
 		require.Zero(t, metrics.GotSignedBlock)
+		require.Equal(t, 1, metrics.MissedBlockCount)
 
 		var block Block
 		require.NoError(t, json.Unmarshal(latestBlockFixture, &block))
@@ -129,10 +195,197 @@ func TestValidatorTask_Run(t *testing.T) {
 		require.NoError(t, err)
 
 		require.Equal(t, 1, metrics.SignedBlockCount)
+		require.Equal(t, 1, metrics.MissedBlockCount)
 		require.Equal(t, "cosmoshub-4", metrics.GotChain)
 		require.Equal(t, addr, metrics.GotAddr)
 
 		require.Equal(t, float64(9001), metrics.GotSignedBlock)
+
+		// The same height is only counted once.
+		err = task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, metrics.SignedBlockCount)
+		require.Equal(t, 1, metrics.MissedBlockCount)
+		require.Empty(t, client.GotHeights)
+	})
+
+	t.Run("happy path - track heights", func(t *testing.T) {
+		chain := Chain{
+			ChainID:      "cosmoshub-4",
+			TrackHeights: true,
+			Validators: []Validator{
+				{ConsAddress: addr},
+			},
+		}
+
+		var signed Block
+		require.NoError(t, json.Unmarshal(latestBlockFixture, &signed))
+		blockAt := func(height string, blk Block) Block {
+			blk.Block.Header.Height = height
+			blk.Block.LastCommit.Height = height
+			return blk
+		}
+
+		client := new(mockValRestClient)
+		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+		client.StubBlock = blockAt("10", signed)
+		client.StubBlockHeight = map[int64]Block{
+			11: blockAt("11", Block{}),
+			12: blockAt("12", signed),
+			13: blockAt("13", Block{}),
+		}
+
+		var metrics mockValMetrics
+		tasks := BuildValidatorTasks(&metrics, client, client, nil, nil, chain)
+		require.Len(t, tasks, 1)
+		task := tasks[0]
+
+		// First run only processes the latest block.
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, metrics.SignedBlockCount)
+		require.Zero(t, metrics.MissedBlockCount)
+		require.Empty(t, client.GotHeights)
+
+		client.StubBlock = blockAt("14", signed)
+
+		err = task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, []int64{11, 12, 13}, client.GotHeights)
+		require.Equal(t, 3, metrics.SignedBlockCount)
+		require.Equal(t, 2, metrics.MissedBlockCount)
+		require.Equal(t, []float64{0, 1, 0, 1, 0}, metrics.GotConsecutiveMisses)
+		require.Equal(t, float64(14), metrics.GotSignedBlock)
+
+		// Skips heights too far behind.
+		client.GotHeights = nil
+		client.StubBlock = blockAt("1000", signed)
+
+		err = task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, client.GotHeights, maxTrackedHeights-1)
+		require.Equal(t, int64(1000-maxTrackedHeights+1), client.GotHeights[0])
+	})
+
+	t.Run("track heights - blocks handled while catching up", func(t *testing.T) {
+		chain := Chain{
+			ChainID:      "cosmoshub-4",
+			TrackHeights: true,
+			Validators:   []Validator{{ConsAddress: addr}},
+		}
+
+		var signed Block
+		require.NoError(t, json.Unmarshal(latestBlockFixture, &signed))
+		blockAt := func(height string) Block {
+			blk := signed
+			blk.Block.Header.Height = height
+			blk.Block.LastCommit.Height = height
+			return blk
+		}
+
+		client := new(mockValRestClient)
+		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+		client.StubBlock = blockAt("10")
+		client.StubBlockHeight = map[int64]Block{11: blockAt("11"), 12: blockAt("12"), 13: blockAt("13"), 14: blockAt("14")}
+
+		var metrics mockValMetrics
+		task := BuildValidatorTasks(&metrics, client, client, nil, nil, chain)[0]
+		require.NoError(t, task.Run(ctx))
+
+		// A streamed block arrives while the run is fetching missed heights. It would deadlock if the
+		// run held the state lock while fetching.
+		var handled bool
+		client.OnBlockByHeight = func(height int64) {
+			if handled {
+				return
+			}
+			handled = true
+			require.NoError(t, task.HandleBlock(ctx, blockAt("15")))
+		}
+		client.StubBlock = blockAt("14")
+		require.NoError(t, task.Run(ctx))
+
+		// Each height is processed exactly once.
+		require.Equal(t, 6, metrics.SignedBlockCount)
+		require.Equal(t, float64(15), metrics.GotSignedBlock)
+	})
+
+	t.Run("happy path - consecutive missed blocks", func(t *testing.T) {
+		chain := Chain{
+			ChainID: "cosmoshub-4",
+			Validators: []Validator{
+				{ConsAddress: addr},
+			},
+		}
+
+		client := new(mockValRestClient)
+		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+
+		var metrics mockValMetrics
+		tasks := BuildValidatorTasks(&metrics, client, client, nil, nil, chain)
+		require.Len(t, tasks, 1)
+		task := tasks[0]
+
+		for i := 1; i <= 5; i++ {
+			client.StubBlock.Block.Header.Height = strconv.Itoa(i)
+			err := task.Run(ctx)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 5, metrics.MissedBlockCount)
+		require.Equal(t, []float64{1, 2, 3, 4, 5}, metrics.GotConsecutiveMisses)
+
+		var signed Block
+		require.NoError(t, json.Unmarshal(latestBlockFixture, &signed))
+		client.StubBlock = signed
+
+		err := task.Run(ctx)
+		require.NoError(t, err)
+
+		require.Equal(t, float64(0), metrics.GotConsecutiveMisses[len(metrics.GotConsecutiveMisses)-1])
+	})
+
+	t.Run("inactive validators do not miss blocks", func(t *testing.T) {
+		const valoper = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+
+		for _, tt := range []struct {
+			Name        string
+			Valoper     string
+			Status      string
+			JailedUntil time.Time
+			WantMissed  int
+		}{
+			{"active", "", "", time.Time{}, 1},
+			{"jailed", "", "", time.Now().Add(time.Hour), 0},
+			{"bonded", valoper, "BOND_STATUS_BONDED", time.Time{}, 1},
+			{"unbonded", valoper, "BOND_STATUS_UNBONDED", time.Time{}, 0},
+		} {
+			var client mockValRestClient
+			client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+			client.StubSigningInfo.ValSigningInfo.JailedUntil = tt.JailedUntil
+			client.StubBlock.Block.Header.Height = "2"
+			client.StubStakingValidator.OperatorAddress = valoper
+			client.StubStakingValidator.Status = tt.Status
+			client.StubStakingValidator.ConsensusPubkey.Type = "/cosmos.crypto.ed25519.PubKey"
+			client.StubStakingValidator.ConsensusPubkey.Key = "Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM="
+
+			var metrics mockValMetrics
+			chain := Chain{
+				ChainID:    "cosmoshub-4",
+				Validators: []Validator{{ConsAddress: addr, Valoper: tt.Valoper}},
+			}
+			tasks := BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
+
+			err := tasks[0].Run(ctx)
+			require.NoError(t, err, tt.Name)
+
+			require.Equal(t, tt.WantMissed, metrics.MissedBlockCount, tt.Name)
+			require.Equal(t, []float64{float64(tt.WantMissed)}, metrics.GotConsecutiveMisses, tt.Name)
+		}
 	})
 
 	t.Run("happy path - jail status", func(t *testing.T) {
@@ -156,6 +409,7 @@ func TestValidatorTask_Run(t *testing.T) {
 
 			var client mockValRestClient
 			client.StubSigningInfo = status
+			client.StubBlock.Block.Header.Height = "2"
 			client.StubBlock.Block.LastCommit.Height = "1"
 
 			var metrics mockValMetrics
@@ -167,7 +421,7 @@ func TestValidatorTask_Run(t *testing.T) {
 				},
 			}
 
-			tasks := BuildValidatorTasks(&metrics, &client, chain)
+			tasks := BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
 
 			require.Len(t, tasks, 1)
 			err := tasks[0].Run(ctx)
@@ -187,6 +441,7 @@ func TestValidatorTask_Run(t *testing.T) {
 
 		var client mockValRestClient
 		client.StubSigningInfo = status
+		client.StubBlock.Block.Header.Height = "2"
 		client.StubBlock.Block.LastCommit.Height = "1"
 
 		var metrics mockValMetrics
@@ -196,7 +451,7 @@ func TestValidatorTask_Run(t *testing.T) {
 				{ConsAddress: addr},
 			},
 		}
-		tasks := BuildValidatorTasks(&metrics, &client, chain)
+		tasks := BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
 
 		require.Len(t, tasks, 1)
 
@@ -208,5 +463,190 @@ func TestValidatorTask_Run(t *testing.T) {
 		require.Equal(t, addr, metrics.GotAddr)
 
 		require.Equal(t, float64(79), metrics.GotMissedBlocks)
+		require.Nil(t, metrics.GotUntilJail)
+	})
+
+	t.Run("happy path - missed blocks until jail", func(t *testing.T) {
+		var status SigningInfo
+		status.ValSigningInfo.MissedBlocksCounter = "79"
+
+		var client mockValRestClient
+		client.StubSigningInfo = status
+		client.StubBlock.Block.Header.Height = "2"
+
+		var slashing SlashingParams
+		slashing.Params.SignedBlocksWindow = "10000"
+		slashing.Params.MinSignedPerWindow = "0.05"
+		params := new(ValParams)
+		params.setSlashing(slashing)
+
+		var metrics mockValMetrics
+		chain := Chain{
+			ChainID: "cosmoshub-4",
+			Validators: []Validator{
+				{ConsAddress: addr},
+			},
+		}
+		tasks := BuildValidatorTasks(&metrics, &client, &client, params, nil, chain)
+
+		require.Len(t, tasks, 1)
+
+		err := tasks[0].Run(ctx)
+		require.NoError(t, err)
+
+		require.NotNil(t, metrics.GotUntilJail)
+		require.Equal(t, float64(9500-79), *metrics.GotUntilJail)
+	})
+
+	t.Run("happy path - resolve consensus address", func(t *testing.T) {
+		const (
+			valoper  = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+			resolved = "cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g"
+		)
+
+		for _, tt := range []struct {
+			ConsAddress  string
+			WantMismatch *bool
+		}{
+			{"", nil},
+			{resolved, new(bool)},
+			{addr, func() *bool { b := true; return &b }()},
+		} {
+			var client mockValRestClient
+			client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+			client.StubBlock.Block.Header.Height = "2"
+			client.StubStakingValidator.OperatorAddress = valoper
+			client.StubStakingValidator.ConsensusPubkey.Type = "/cosmos.crypto.ed25519.PubKey"
+			client.StubStakingValidator.ConsensusPubkey.Key = "Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM="
+
+			var metrics mockValMetrics
+			chain := Chain{
+				ChainID: "cosmoshub-4",
+				Validators: []Validator{
+					{ConsAddress: tt.ConsAddress, Valoper: valoper},
+				},
+			}
+			tasks := BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
+			require.Len(t, tasks, 1)
+
+			err := tasks[0].Run(ctx)
+			require.NoError(t, err, tt)
+
+			require.Equal(t, resolved, client.SigningInfoAddress, tt)
+			require.Equal(t, resolved, metrics.GotAddr, tt)
+			require.Equal(t, tt.WantMismatch, metrics.GotMismatch, tt)
+
+			// Resolved address is reused.
+			err = tasks[0].Run(ctx)
+			require.NoError(t, err, tt)
+			require.Equal(t, 1, client.StakingValidatorCalls, tt)
+		}
+	})
+
+	t.Run("resolve consensus address error", func(t *testing.T) {
+		const valoper = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+
+		var client mockValRestClient
+		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+		client.StubBlock.Block.Header.Height = "2"
+		client.StubStakingErr = errors.New("boom")
+
+		var metrics mockValMetrics
+		chain := Chain{
+			ChainID:    "cosmoshub-4",
+			Validators: []Validator{{Valoper: valoper}},
+		}
+		tasks := BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
+		require.Len(t, tasks, 1)
+		require.Equal(t, valoper, tasks[0].ID())
+
+		err := tasks[0].Run(ctx)
+		require.EqualError(t, err, "resolve consensus address: boom")
+
+		// Falls back to the configured address.
+		chain.Validators[0].ConsAddress = addr
+		tasks = BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
+
+		err = tasks[0].Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, addr, client.SigningInfoAddress)
+
+		// Failures are not retried on every run.
+		calls := client.StakingValidatorCalls
+		err = tasks[0].Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, calls, client.StakingValidatorCalls)
+	})
+
+	t.Run("consensus address change", func(t *testing.T) {
+		const (
+			valoper  = "cosmosvaloper1xyerxdp4xcmnswfsxyerxdp4xcmnswfs008wpw"
+			resolved = "cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g"
+		)
+
+		var client mockValRestClient
+		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+		client.StubBlock.Block.Header.Height = "2"
+		client.StubStakingErr = errors.New("boom")
+
+		var metrics mockValMetrics
+		chain := Chain{
+			ChainID:    "cosmoshub-4",
+			Validators: []Validator{{ConsAddress: addr, Valoper: valoper}},
+		}
+		tasks := BuildValidatorTasks(&metrics, &client, &client, nil, nil, chain)
+
+		err := tasks[0].Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, addr, client.SigningInfoAddress)
+		require.Empty(t, metrics.GotDeleted)
+
+		// Resolves the rotated key once the retry is due.
+		client.StubStakingErr = nil
+		client.StubStakingValidator.OperatorAddress = valoper
+		client.StubStakingValidator.ConsensusPubkey.Type = "/cosmos.crypto.ed25519.PubKey"
+		client.StubStakingValidator.ConsensusPubkey.Key = "Ft6qCsTV8qT7fbYY8eCRj3Axb8TUEC7YLBD6z8fz6pM="
+		tasks[0].state.resolveAt = time.Time{}
+
+		err = tasks[0].Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, resolved, client.SigningInfoAddress)
+		require.Equal(t, []string{addr}, metrics.GotDeleted)
+	})
+
+	t.Run("happy path - streaming", func(t *testing.T) {
+		chain := Chain{
+			ChainID:    "cosmoshub-4",
+			Validators: []Validator{{ConsAddress: addr}},
+		}
+		stream := NewBlockStream(nil, nil, chain)
+
+		var client mockValRestClient
+		client.StubSigningInfo.ValSigningInfo.MissedBlocksCounter = "0"
+		var metrics mockValMetrics
+		tasks := BuildValidatorTasks(&metrics, &client, &client, nil, stream, chain)
+		require.Len(t, tasks, 1)
+		require.Len(t, stream.handlers, 1)
+
+		var block Block
+		require.NoError(t, json.Unmarshal(latestBlockFixture, &block))
+		err := stream.handlers[0].HandleBlock(ctx, block)
+		require.NoError(t, err)
+		require.Equal(t, 1, metrics.SignedBlockCount)
+
+		// While connected, blocks are not polled.
+		stream.connected.Store(true)
+		client.StubBlock.Block.Header.Height = "99999999"
+		err = tasks[0].Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, metrics.SignedBlockCount)
+		require.Zero(t, metrics.MissedBlockCount)
+		require.Equal(t, addr, client.SigningInfoAddress)
+
+		// Falls back to polling when disconnected.
+		stream.connected.Store(false)
+		err = tasks[0].Run(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, metrics.MissedBlockCount)
 	})
 }
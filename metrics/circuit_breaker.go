@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"sync"
+)
+
+// CircuitState is the health of a host as tracked by a FallbackClient.
+type CircuitState int
+
+const (
+	// CircuitClosed means the host is healthy and receives requests.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means the host failed repeatedly and is skipped until a background probe succeeds.
+	CircuitOpen
+	// CircuitHalfOpen means a background probe is in flight. The host is skipped until the probe succeeds.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+var circuitStates = []CircuitState{CircuitClosed, CircuitOpen, CircuitHalfOpen}
+
+// circuitFailureThreshold is the number of consecutive failures that opens the circuit for a host.
+const circuitFailureThreshold = 5
+
+// circuitBreaker tracks consecutive failures of a single host.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	onChange func(CircuitState)
+}
+
+func newCircuitBreaker(onChange func(CircuitState)) *circuitBreaker {
+	onChange(CircuitClosed)
+	return &circuitBreaker{onChange: onChange}
+}
+
+// allow returns true if requests may be sent to the host.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == CircuitClosed
+}
+
+// success resets failures and closes the circuit.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.setState(CircuitClosed)
+}
+
+// failure records a failed request. Returns true if the failure opened the circuit.
+func (b *circuitBreaker) failure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	switch b.state {
+	case CircuitClosed:
+		if b.failures < circuitFailureThreshold {
+			return false
+		}
+	case CircuitOpen:
+		return false
+	}
+	b.setState(CircuitOpen)
+	return true
+}
+
+// probe moves an open circuit to half-open. Returns false if the circuit is not open.
+func (b *circuitBreaker) probe() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != CircuitOpen {
+		return false
+	}
+	b.setState(CircuitHalfOpen)
+	return true
+}
+
+func (b *circuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	b.onChange(state)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var got []CircuitState
+	breaker := newCircuitBreaker(func(state CircuitState) { got = append(got, state) })
+
+	require.True(t, breaker.allow())
+	require.False(t, breaker.probe())
+
+	for i := 0; i < circuitFailureThreshold-1; i++ {
+		require.False(t, breaker.failure())
+	}
+	breaker.success()
+	require.True(t, breaker.allow())
+
+	for i := 0; i < circuitFailureThreshold-1; i++ {
+		require.False(t, breaker.failure())
+	}
+	require.True(t, breaker.failure())
+	require.False(t, breaker.allow())
+	require.False(t, breaker.failure())
+
+	require.True(t, breaker.probe())
+	require.False(t, breaker.allow())
+	require.True(t, breaker.failure())
+
+	require.True(t, breaker.probe())
+	breaker.success()
+	require.True(t, breaker.allow())
+
+	require.Equal(t, []CircuitState{
+		CircuitClosed, CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed,
+	}, got)
+}
@@ -1,6 +1,11 @@
 package metrics
 
 import (
+	"maps"
+	"net/url"
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/strangelove-ventures/sl-exporter/cosmos"
 )
@@ -8,12 +13,52 @@ import (
 // Cosmos records metrics for Cosmos chains
 type Cosmos struct {
 	accountBalance      *prometheus.GaugeVec
+	accountDisplay      *prometheus.GaugeVec
 	heightGauge         *prometheus.GaugeVec
 	valJailGauge        *prometheus.GaugeVec
 	valBlockSignCounter *prometheus.CounterVec
 	valSignedBlock      *prometheus.GaugeVec
+	valBlockMissCounter *prometheus.CounterVec
+	valConsecutiveMiss  *prometheus.GaugeVec
 	valMissedBlocks     *prometheus.GaugeVec
 	valSlashingWindow   *prometheus.GaugeVec
+	valMissedUntilJail  *prometheus.GaugeVec
+
+	valMinSignedPerWindow   *prometheus.GaugeVec
+	valDowntimeJailDuration *prometheus.GaugeVec
+	valSlashDoubleSign      *prometheus.GaugeVec
+	valSlashDowntime        *prometheus.GaugeVec
+
+	valBondStatus      *prometheus.GaugeVec
+	valTokens          *prometheus.GaugeVec
+	valDelegatorShares *prometheus.GaugeVec
+	valCommission      *prometheus.GaugeVec
+	valMaxCommission   *prometheus.GaugeVec
+	valRank            *prometheus.GaugeVec
+
+	valConsAddrMismatch *prometheus.GaugeVec
+
+	govProposalEndTime   *prometheus.GaugeVec
+	govProposalRemaining *prometheus.GaugeVec
+	govVoted             *prometheus.GaugeVec
+
+	upgradeInfo     *prometheus.GaugeVec
+	upgradeHeight   *prometheus.GaugeVec
+	upgradeEstimate *prometheus.GaugeVec
+
+	latestBlockTime  *prometheus.GaugeVec
+	sinceLastBlock   *prometheus.GaugeVec
+	avgBlockInterval *prometheus.GaugeVec
+
+	endpointHeight   *prometheus.GaugeVec
+	endpointBlockLag *prometheus.GaugeVec
+
+	accountDelegated       *prometheus.GaugeVec
+	accountUnbonding       *prometheus.GaugeVec
+	accountUnbondingEnd    *prometheus.GaugeVec
+	accountRedelegating    *prometheus.GaugeVec
+	accountRedelegationEnd *prometheus.GaugeVec
+	accountRewards         *prometheus.GaugeVec
 }
 
 func NewCosmos() *Cosmos {
@@ -21,9 +66,58 @@ func NewCosmos() *Cosmos {
 		accountBalance: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_balance"),
-				Help: "Latest balance for a cosmos account.",
+				Help: "Latest balance for a cosmos account in base units, e.g. uatom. For IBC denoms, base_denom is the denom on the source chain. display_denom is empty if the chain has no denom metadata.",
+			},
+			[]string{"chain_id", "alias", "address", "denom", "base_denom", "display_denom"},
+		),
+		accountDisplay: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_balance_display"),
+				Help: "Latest balance for a cosmos account in display units, e.g. atom. Only recorded for denoms with metadata.",
 			},
-			[]string{"chain_id", "alias", "address", "denom"},
+			[]string{"chain_id", "alias", "address", "denom", "base_denom", "display_denom"},
+		),
+		accountDelegated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_delegated"),
+				Help: "Amount a cosmos account delegated to a validator.",
+			},
+			[]string{"chain_id", "alias", "address", "validator", "denom"},
+		),
+		accountUnbonding: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_unbonding"),
+				Help: "Amount a cosmos account is unbonding from a validator, partitioned by the height the unbonding started.",
+			},
+			[]string{"chain_id", "alias", "address", "validator", "denom", "creation_height"},
+		),
+		accountUnbondingEnd: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_unbonding_completion_time"),
+				Help: "Unix timestamp in seconds when an unbonding entry of a cosmos account completes.",
+			},
+			[]string{"chain_id", "alias", "address", "validator", "creation_height"},
+		),
+		accountRedelegating: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_redelegating"),
+				Help: "Amount a cosmos account is redelegating between validators, partitioned by the height the redelegation started.",
+			},
+			[]string{"chain_id", "alias", "address", "src_validator", "dst_validator", "denom", "creation_height"},
+		),
+		accountRedelegationEnd: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_redelegation_completion_time"),
+				Help: "Unix timestamp in seconds when a redelegation entry of a cosmos account completes.",
+			},
+			[]string{"chain_id", "alias", "address", "src_validator", "dst_validator", "creation_height"},
+		),
+		accountRewards: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "account_staking_rewards"),
+				Help: "Claimable staking rewards of a cosmos account from a validator.",
+			},
+			[]string{"chain_id", "alias", "address", "validator", "denom"},
 		),
 		heightGauge: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -46,6 +140,20 @@ func NewCosmos() *Cosmos {
 			},
 			[]string{"chain_id", "address"},
 		),
+		valBlockMissCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "missed_blocks_total"),
+				Help: "Count of observed blocks missed by a cosmos validator.",
+			},
+			[]string{"chain_id", "address"},
+		),
+		valConsecutiveMiss: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "consecutive_missed_blocks"),
+				Help: "The number of consecutive observed blocks missed by a cosmos validator. Resets to 0 when the validator signs a block.",
+			},
+			[]string{"chain_id", "address"},
+		),
 		valSignedBlock: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "signed_block_height"),
@@ -67,12 +175,280 @@ func NewCosmos() *Cosmos {
 			},
 			[]string{"chain_id"},
 		),
+		valMissedUntilJail: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "missed_blocks_until_jail"),
+				Help: "The number of additional blocks a cosmos validator may miss within the slashing window before being jailed.",
+			},
+			[]string{"chain_id", "address"},
+		),
+		valMinSignedPerWindow: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "min_signed_per_window_ratio"),
+				Help: "The minimum ratio of blocks a cosmos validator must sign within the slashing window to avoid jailing.",
+			},
+			[]string{"chain_id"},
+		),
+		valDowntimeJailDuration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "downtime_jail_duration_seconds"),
+				Help: "How long a cosmos validator is jailed for downtime.",
+			},
+			[]string{"chain_id"},
+		),
+		valSlashDoubleSign: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "slash_fraction_double_sign_ratio"),
+				Help: "The fraction of stake slashed from a cosmos validator for double signing.",
+			},
+			[]string{"chain_id"},
+		),
+		valSlashDowntime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "slash_fraction_downtime_ratio"),
+				Help: "The fraction of stake slashed from a cosmos validator for downtime.",
+			},
+			[]string{"chain_id"},
+		),
+		valBondStatus: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "bond_status"),
+				Help: "1 if the cosmos validator is unbonded. 2 if the validator is unbonding. 3 if the validator is bonded (part of the active set).",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		valTokens: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "tokens"),
+				Help: "Tokens delegated to a cosmos validator in the base unit of the staking denom.",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		valDelegatorShares: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "delegator_shares"),
+				Help: "Total shares issued to a cosmos validator's delegators.",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		valCommission: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "commission_rate_ratio"),
+				Help: "The commission rate charged to delegators by a cosmos validator.",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		valMaxCommission: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "commission_max_rate_ratio"),
+				Help: "The maximum commission rate a cosmos validator can ever charge.",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		valRank: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "voting_power_rank"),
+				Help: "The rank by voting power of a cosmos validator within the active set, starting at 1. 0 if the validator is not in the active set.",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		valConsAddrMismatch: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosValSubsystem, "consensus_address_mismatch"),
+				Help: "1 if the configured consensus address of a cosmos validator does not match the address resolved from its operator address, otherwise 0.",
+			},
+			[]string{"chain_id", "valoper"},
+		),
+		govProposalEndTime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosGovSubsystem, "proposal_voting_end_timestamp_seconds"),
+				Help: "Unix timestamp when voting ends for a cosmos governance proposal in the voting period.",
+			},
+			[]string{"chain_id", "proposal_id", "title"},
+		),
+		govProposalRemaining: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosGovSubsystem, "proposal_voting_remaining_seconds"),
+				Help: "Seconds until voting ends for a cosmos governance proposal in the voting period, as of the last poll.",
+			},
+			[]string{"chain_id", "proposal_id"},
+		),
+		govVoted: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosGovSubsystem, "proposal_voted"),
+				Help: "1 if a configured validator or account voted on a cosmos governance proposal in the voting period, otherwise 0.",
+			},
+			[]string{"chain_id", "proposal_id", "address", "alias"},
+		),
+		upgradeInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "upgrade_plan_info"),
+				Help: "Always 1 while a cosmos software upgrade is scheduled. The name label is the upgrade plan name.",
+			},
+			[]string{"chain_id", "name"},
+		),
+		upgradeHeight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "upgrade_plan_height"),
+				Help: "Block height of a scheduled cosmos software upgrade.",
+			},
+			[]string{"chain_id"},
+		),
+		upgradeEstimate: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "upgrade_plan_estimated_remaining_seconds"),
+				Help: "Estimated seconds until a scheduled cosmos software upgrade based on the recent average block time.",
+			},
+			[]string{"chain_id"},
+		),
+		latestBlockTime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "latest_block_timestamp_seconds"),
+				Help: "Unix timestamp from the header of the latest block of a cosmos node.",
+			},
+			[]string{"chain_id"},
+		),
+		sinceLastBlock: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "seconds_since_latest_block"),
+				Help: "Seconds between the latest block of a cosmos node and the last poll. Continually increases if the chain halts.",
+			},
+			[]string{"chain_id"},
+		),
+		avgBlockInterval: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "block_interval_seconds"),
+				Help: "Rolling average of seconds between recently observed blocks of a cosmos chain.",
+			},
+			[]string{"chain_id"},
+		),
+		endpointHeight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "endpoint_latest_block_height"),
+				Help: "Latest block height of a cosmos REST endpoint.",
+			},
+			[]string{"chain_id", "host"},
+		),
+		endpointBlockLag: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, cosmosSubsystem, "endpoint_blocks_behind"),
+				Help: "Blocks a cosmos REST endpoint is behind the highest REST endpoint of the chain.",
+			},
+			[]string{"chain_id", "host"},
+		),
 	}
 }
 
 // SetAccountBalance records the balance of an account for a given denom.
-func (c *Cosmos) SetAccountBalance(chain, alias, address, denom string, balance float64) {
-	c.accountBalance.WithLabelValues(chain, alias, address, denom).Set(balance)
+func (c *Cosmos) SetAccountBalance(chain, alias string, balance cosmos.AccountBalance) {
+	keepBalance, keepDisplay := make(seriesSet), make(seriesSet)
+	c.setAccountBalance(chain, alias, balance, keepBalance, keepDisplay)
+	// Delete series with stale labels in case the denom metadata changed.
+	match := prometheus.Labels{"chain_id": chain, "alias": alias, "address": balance.Account, "denom": balance.Denom}
+	deleteVanished(c.accountBalance.MetricVec, match, keepBalance)
+	deleteVanished(c.accountDisplay.MetricVec, match, keepDisplay)
+}
+
+// SetAccountBalances replaces all balances of an account. Balances of denoms not in balances are removed.
+func (c *Cosmos) SetAccountBalances(chain, alias, address string, balances []cosmos.AccountBalance) {
+	keepBalance, keepDisplay := make(seriesSet), make(seriesSet)
+	for _, bal := range balances {
+		c.setAccountBalance(chain, alias, bal, keepBalance, keepDisplay)
+	}
+	match := prometheus.Labels{"chain_id": chain, "alias": alias, "address": address}
+	deleteVanished(c.accountBalance.MetricVec, match, keepBalance)
+	deleteVanished(c.accountDisplay.MetricVec, match, keepDisplay)
+}
+
+// setAccountBalance sets the balance gauges and adds the label sets it set to keepBalance and keepDisplay.
+func (c *Cosmos) setAccountBalance(chain, alias string, balance cosmos.AccountBalance, keepBalance, keepDisplay seriesSet) {
+	labels := prometheus.Labels{
+		"chain_id":      chain,
+		"alias":         alias,
+		"address":       balance.Account,
+		"denom":         balance.Denom,
+		"base_denom":    balance.Metadata.Base,
+		"display_denom": balance.Metadata.Display,
+	}
+	c.accountBalance.With(labels).Set(balance.Amount)
+	keepBalance.add(labels)
+	if amount, ok := balance.DisplayAmount(); ok {
+		c.accountDisplay.With(labels).Set(amount)
+		keepDisplay.add(labels)
+	}
+}
+
+// SetAccountStaking replaces the staking metrics of an account. Completed unbondings and redelegations,
+// and validators the account no longer delegates to, are removed.
+func (c *Cosmos) SetAccountStaking(chain, alias, address string, staking cosmos.AccountStaking) {
+	account := prometheus.Labels{"chain_id": chain, "alias": alias, "address": address}
+	withAccount := func(labels prometheus.Labels) prometheus.Labels {
+		maps.Copy(labels, account)
+		return labels
+	}
+
+	keep := make(seriesSet)
+	for _, d := range staking.Delegations {
+		labels := withAccount(prometheus.Labels{"validator": d.Validator, "denom": d.Denom})
+		c.accountDelegated.With(labels).Set(d.Amount)
+		keep.add(labels)
+	}
+	deleteVanished(c.accountDelegated.MetricVec, account, keep)
+
+	// Entries created at the same height are added together.
+	var (
+		amounts = make(map[string]float64)
+		labels  = make(map[string]prometheus.Labels)
+		keepEnd = make(seriesSet)
+	)
+	for _, e := range staking.Unbondings {
+		height := strconv.FormatInt(e.CreationHeight, 10)
+		l := withAccount(prometheus.Labels{"validator": e.Validator, "denom": staking.BondDenom, "creation_height": height})
+		key := seriesKey(l)
+		amounts[key] += e.Amount
+		labels[key] = l
+		end := withAccount(prometheus.Labels{"validator": e.Validator, "creation_height": height})
+		c.accountUnbondingEnd.With(end).Set(float64(e.CompletionTime.Unix()))
+		keepEnd.add(end)
+	}
+	keep = setSums(c.accountUnbonding, labels, amounts)
+	deleteVanished(c.accountUnbonding.MetricVec, account, keep)
+	deleteVanished(c.accountUnbondingEnd.MetricVec, account, keepEnd)
+
+	amounts, labels, keepEnd = make(map[string]float64), make(map[string]prometheus.Labels), make(seriesSet)
+	for _, e := range staking.Redelegations {
+		height := strconv.FormatInt(e.CreationHeight, 10)
+		l := withAccount(prometheus.Labels{
+			"src_validator": e.SrcValidator, "dst_validator": e.DstValidator, "denom": staking.BondDenom, "creation_height": height,
+		})
+		key := seriesKey(l)
+		amounts[key] += e.Amount
+		labels[key] = l
+		end := withAccount(prometheus.Labels{"src_validator": e.SrcValidator, "dst_validator": e.DstValidator, "creation_height": height})
+		c.accountRedelegationEnd.With(end).Set(float64(e.CompletionTime.Unix()))
+		keepEnd.add(end)
+	}
+	keep = setSums(c.accountRedelegating, labels, amounts)
+	deleteVanished(c.accountRedelegating.MetricVec, account, keep)
+	deleteVanished(c.accountRedelegationEnd.MetricVec, account, keepEnd)
+
+	keep = make(seriesSet)
+	for _, r := range staking.Rewards {
+		labels := withAccount(prometheus.Labels{"validator": r.Validator, "denom": r.Denom})
+		c.accountRewards.With(labels).Set(r.Amount)
+		keep.add(labels)
+	}
+	deleteVanished(c.accountRewards.MetricVec, account, keep)
+}
+
+// setSums sets the gauges of vec to amounts, keyed by seriesKey of labels, and returns the label sets it set.
+func setSums(vec *prometheus.GaugeVec, labels map[string]prometheus.Labels, amounts map[string]float64) seriesSet {
+	set := make(seriesSet, len(amounts))
+	for key, amount := range amounts {
+		vec.With(labels[key]).Set(amount)
+		set[key] = struct{}{}
+	}
+	return set
 }
 
 // SetNodeHeight records the block height on the public_rpc_node_height gauge.
@@ -80,6 +456,31 @@ func (c *Cosmos) SetNodeHeight(chain string, height float64) {
 	c.heightGauge.WithLabelValues(chain).Set(height)
 }
 
+// SetLatestBlockTime records the timestamp of the latest block.
+func (c *Cosmos) SetLatestBlockTime(chain string, timestamp float64) {
+	c.latestBlockTime.WithLabelValues(chain).Set(timestamp)
+}
+
+// SetSecondsSinceLastBlock records the seconds since the latest block.
+func (c *Cosmos) SetSecondsSinceLastBlock(chain string, seconds float64) {
+	c.sinceLastBlock.WithLabelValues(chain).Set(seconds)
+}
+
+// SetAvgBlockInterval records the rolling average of seconds between blocks.
+func (c *Cosmos) SetAvgBlockInterval(chain string, seconds float64) {
+	c.avgBlockInterval.WithLabelValues(chain).Set(seconds)
+}
+
+// SetEndpointHeight records the latest block height of a single endpoint.
+func (c *Cosmos) SetEndpointHeight(chain string, host url.URL, height float64) {
+	c.endpointHeight.WithLabelValues(chain, host.Hostname()).Set(height)
+}
+
+// SetEndpointBlockLag records how many blocks an endpoint is behind the highest endpoint.
+func (c *Cosmos) SetEndpointBlockLag(chain string, host url.URL, blocks float64) {
+	c.endpointBlockLag.WithLabelValues(chain, host.Hostname()).Set(blocks)
+}
+
 // SetValJailStatus records the jailed status of a validator.
 // In this context, "active" does not mean part of the validator active set, only that the validator is not jailed.
 func (c *Cosmos) SetValJailStatus(chain, consaddress string, status cosmos.JailStatus) {
@@ -91,6 +492,16 @@ func (c *Cosmos) IncValSignedBlocks(chain, consaddress string) {
 	c.valBlockSignCounter.WithLabelValues(chain, consaddress).Inc()
 }
 
+// IncValMissedBlocks increments the number of blocks missed by validator at consaddress.
+func (c *Cosmos) IncValMissedBlocks(chain, consaddress string) {
+	c.valBlockMissCounter.WithLabelValues(chain, consaddress).Inc()
+}
+
+// SetValConsecutiveMissedBlocks sets the number of consecutive blocks missed by a validator.
+func (c *Cosmos) SetValConsecutiveMissedBlocks(chain, consaddress string, missed float64) {
+	c.valConsecutiveMiss.WithLabelValues(chain, consaddress).Set(missed)
+}
+
 // SetValSignedBlock sets latest signed block height for a validator.
 func (c *Cosmos) SetValSignedBlock(chain, consaddress string, height float64) {
 	c.valSignedBlock.WithLabelValues(chain, consaddress).Set(height)
@@ -101,10 +512,114 @@ func (c *Cosmos) SetValMissedBlocks(chain, consaddress string, missed float64) {
 	c.valMissedBlocks.WithLabelValues(chain, consaddress).Set(missed)
 }
 
-// SetValSlashingParams sets the slashing window for all validators on the chain.
-// Extend this method to set other slashing parameters.
-func (c *Cosmos) SetValSlashingParams(chain string, window float64) {
-	c.valSlashingWindow.WithLabelValues(chain).Set(window)
+// SetValMissedBlocksUntilJail sets the number of blocks a validator may miss before being jailed.
+func (c *Cosmos) SetValMissedBlocksUntilJail(chain, consaddress string, blocks float64) {
+	c.valMissedUntilJail.WithLabelValues(chain, consaddress).Set(blocks)
+}
+
+// DeleteValMetrics deletes all metrics of a validator consensus address.
+func (c *Cosmos) DeleteValMetrics(chain, consaddress string) {
+	labels := prometheus.Labels{"chain_id": chain, "address": consaddress}
+	c.valJailGauge.DeletePartialMatch(labels)
+	c.valBlockSignCounter.DeletePartialMatch(labels)
+	c.valSignedBlock.DeletePartialMatch(labels)
+	c.valBlockMissCounter.DeletePartialMatch(labels)
+	c.valConsecutiveMiss.DeletePartialMatch(labels)
+	c.valMissedBlocks.DeletePartialMatch(labels)
+	c.valMissedUntilJail.DeletePartialMatch(labels)
+}
+
+// SetValSlashingParams sets the slashing parameters for all validators on the chain.
+func (c *Cosmos) SetValSlashingParams(chain string, params cosmos.SlashingParams) {
+	c.valSlashingWindow.WithLabelValues(chain).Set(params.SignedBlocksWindow())
+	c.valMinSignedPerWindow.WithLabelValues(chain).Set(params.MinSignedPerWindow())
+	c.valDowntimeJailDuration.WithLabelValues(chain).Set(params.DowntimeJailDuration().Seconds())
+	c.valSlashDoubleSign.WithLabelValues(chain).Set(params.SlashFractionDoubleSign())
+	c.valSlashDowntime.WithLabelValues(chain).Set(params.SlashFractionDowntime())
+}
+
+// SetValBondStatus records the staking bond status of a validator.
+func (c *Cosmos) SetValBondStatus(chain, valoper string, status cosmos.BondStatus) {
+	c.valBondStatus.WithLabelValues(chain, valoper).Set(float64(status))
+}
+
+// SetValTokens sets the tokens delegated to a validator.
+func (c *Cosmos) SetValTokens(chain, valoper string, tokens float64) {
+	c.valTokens.WithLabelValues(chain, valoper).Set(tokens)
+}
+
+// SetValDelegatorShares sets the total delegator shares of a validator.
+func (c *Cosmos) SetValDelegatorShares(chain, valoper string, shares float64) {
+	c.valDelegatorShares.WithLabelValues(chain, valoper).Set(shares)
+}
+
+// SetValCommission sets the commission rate and max rate of a validator.
+func (c *Cosmos) SetValCommission(chain, valoper string, rate, maxRate float64) {
+	c.valCommission.WithLabelValues(chain, valoper).Set(rate)
+	c.valMaxCommission.WithLabelValues(chain, valoper).Set(maxRate)
+}
+
+// SetValRank sets the voting power rank of a validator within the active set.
+func (c *Cosmos) SetValRank(chain, valoper string, rank float64) {
+	c.valRank.WithLabelValues(chain, valoper).Set(rank)
+}
+
+// SetValConsAddressMismatch records whether the configured consensus address of a validator differs from
+// the consensus address resolved from its operator address.
+func (c *Cosmos) SetValConsAddressMismatch(chain, valoper string, mismatch bool) {
+	var v float64
+	if mismatch {
+		v = 1
+	}
+	c.valConsAddrMismatch.WithLabelValues(chain, valoper).Set(v)
+}
+
+// SetGovProposals replaces all governance proposal metrics for a chain.
+// Proposals no longer in the voting period are removed.
+func (c *Cosmos) SetGovProposals(chain string, proposals []cosmos.Proposal, votes map[uint64][]cosmos.ProposalVote) {
+	keepEndTime, keepRemaining, keepVoted := make(seriesSet), make(seriesSet), make(seriesSet)
+	for _, proposal := range proposals {
+		id := strconv.FormatUint(proposal.ID, 10)
+		endTime := prometheus.Labels{"chain_id": chain, "proposal_id": id, "title": proposal.Title}
+		c.govProposalEndTime.With(endTime).Set(float64(proposal.VotingEndTime.Unix()))
+		keepEndTime.add(endTime)
+		remaining := prometheus.Labels{"chain_id": chain, "proposal_id": id}
+		c.govProposalRemaining.With(remaining).Set(time.Until(proposal.VotingEndTime).Seconds())
+		keepRemaining.add(remaining)
+		for _, vote := range votes[proposal.ID] {
+			var voted float64
+			if vote.Voted {
+				voted = 1
+			}
+			labels := prometheus.Labels{"chain_id": chain, "proposal_id": id, "address": vote.Voter.Address, "alias": vote.Voter.Alias}
+			c.govVoted.With(labels).Set(voted)
+			keepVoted.add(labels)
+		}
+	}
+
+	chainLabel := prometheus.Labels{"chain_id": chain}
+	deleteVanished(c.govProposalEndTime.MetricVec, chainLabel, keepEndTime)
+	deleteVanished(c.govProposalRemaining.MetricVec, chainLabel, keepRemaining)
+	deleteVanished(c.govVoted.MetricVec, chainLabel, keepVoted)
+}
+
+// SetUpgradePlan records a scheduled software upgrade.
+func (c *Cosmos) SetUpgradePlan(chain, name string, height, estimatedSeconds float64) {
+	info := prometheus.Labels{"chain_id": chain, "name": name}
+	c.upgradeInfo.With(info).Set(1)
+	c.upgradeHeight.WithLabelValues(chain).Set(height)
+	c.upgradeEstimate.WithLabelValues(chain).Set(estimatedSeconds)
+	// Delete the previous plan in case the plan name changed.
+	keep := make(seriesSet)
+	keep.add(info)
+	deleteVanished(c.upgradeInfo.MetricVec, prometheus.Labels{"chain_id": chain}, keep)
+}
+
+// ClearUpgradePlan removes upgrade metrics for a chain with no scheduled upgrade.
+func (c *Cosmos) ClearUpgradePlan(chain string) {
+	deleteVanished(c.upgradeInfo.MetricVec, prometheus.Labels{"chain_id": chain}, nil)
+	c.upgradeHeight.DeleteLabelValues(chain)
+	c.upgradeEstimate.DeleteLabelValues(chain)
 }
 
 // Metrics returns all metrics for Cosmos chains to be added to a Prometheus registry.
@@ -117,5 +632,37 @@ func (c *Cosmos) Metrics() []prometheus.Collector {
 		c.valMissedBlocks,
 		c.valSlashingWindow,
 		c.accountBalance,
+		c.valBlockMissCounter,
+		c.valConsecutiveMiss,
+		c.valMissedUntilJail,
+		c.valMinSignedPerWindow,
+		c.valDowntimeJailDuration,
+		c.valSlashDoubleSign,
+		c.valSlashDowntime,
+		c.valBondStatus,
+		c.valTokens,
+		c.valDelegatorShares,
+		c.valCommission,
+		c.valMaxCommission,
+		c.valRank,
+		c.valConsAddrMismatch,
+		c.govProposalEndTime,
+		c.govProposalRemaining,
+		c.govVoted,
+		c.upgradeInfo,
+		c.upgradeHeight,
+		c.upgradeEstimate,
+		c.latestBlockTime,
+		c.sinceLastBlock,
+		c.avgBlockInterval,
+		c.endpointHeight,
+		c.endpointBlockLag,
+		c.accountDisplay,
+		c.accountDelegated,
+		c.accountUnbonding,
+		c.accountUnbondingEnd,
+		c.accountRedelegating,
+		c.accountRedelegationEnd,
+		c.accountRewards,
 	}
 }
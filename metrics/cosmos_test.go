@@ -3,7 +3,9 @@ package metrics
 import (
 	"fmt"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/strangelove-ventures/sl-exporter/cosmos"
@@ -15,7 +17,7 @@ func TestCosmos_SetNodeHeight(t *testing.T) {
 
 	reg := prometheus.NewRegistry()
 	metrics := NewCosmos()
-	reg.MustRegister(metrics.Metrics()[0])
+	reg.MustRegister(metrics.Metrics()...)
 
 	metrics.SetNodeHeight("cosmoshub-4", 12345)
 
@@ -27,12 +29,57 @@ func TestCosmos_SetNodeHeight(t *testing.T) {
 	require.Contains(t, r.Body.String(), want)
 }
 
+func TestCosmos_BlockTime(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	metrics := NewCosmos()
+	reg.MustRegister(metrics.Metrics()...)
+
+	metrics.SetLatestBlockTime("cosmoshub-4", 1700000000.5)
+	metrics.SetSecondsSinceLastBlock("cosmoshub-4", 3.5)
+	metrics.SetAvgBlockInterval("cosmoshub-4", 6.25)
+
+	h := metricsHandler(reg)
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	for _, want := range []string{
+		`sl_exporter_cosmos_latest_block_timestamp_seconds{chain_id="cosmoshub-4"} 1.7000000005e+09`,
+		`sl_exporter_cosmos_seconds_since_latest_block{chain_id="cosmoshub-4"} 3.5`,
+		`sl_exporter_cosmos_block_interval_seconds{chain_id="cosmoshub-4"} 6.25`,
+	} {
+		require.Contains(t, r.Body.String(), want)
+	}
+}
+
+func TestCosmos_EndpointHeight(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	metrics := NewCosmos()
+	reg.MustRegister(metrics.Metrics()...)
+
+	u, err := url.Parse("https://api.example.com:443")
+	require.NoError(t, err)
+
+	metrics.SetEndpointHeight("cosmoshub-4", *u, 12345)
+	metrics.SetEndpointBlockLag("cosmoshub-4", *u, 25)
+
+	h := metricsHandler(reg)
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_endpoint_latest_block_height{chain_id="cosmoshub-4",host="api.example.com"} 12345`)
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_endpoint_blocks_behind{chain_id="cosmoshub-4",host="api.example.com"} 25`)
+}
+
 func TestCosmos_SetValJailStatus(t *testing.T) {
 	t.Parallel()
 
 	metrics := NewCosmos()
 	reg := prometheus.NewRegistry()
-	reg.MustRegister(metrics.Metrics()[1])
+	reg.MustRegister(metrics.Metrics()...)
 	h := metricsHandler(reg)
 
 	for _, tt := range []struct {
@@ -60,7 +107,7 @@ func TestCosmos_IncValSignedBlocks(t *testing.T) {
 
 	metrics := NewCosmos()
 	reg := prometheus.NewRegistry()
-	reg.MustRegister(metrics.Metrics()[2])
+	reg.MustRegister(metrics.Metrics()...)
 	h := metricsHandler(reg)
 
 	// Purposefully calling twice
@@ -74,12 +121,48 @@ func TestCosmos_IncValSignedBlocks(t *testing.T) {
 	require.Contains(t, r.Body.String(), want)
 }
 
+func TestCosmos_IncValMissedBlocks(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	// Purposefully calling twice
+	metrics.IncValMissedBlocks("cosmoshub-4", "cosmosvalcons123")
+	metrics.IncValMissedBlocks("cosmoshub-4", "cosmosvalcons123")
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	const want = `sl_exporter_cosmos_val_missed_blocks_total{address="cosmosvalcons123",chain_id="cosmoshub-4"} 2`
+	require.Contains(t, r.Body.String(), want)
+}
+
+func TestCosmos_SetValConsecutiveMissedBlocks(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	metrics.SetValConsecutiveMissedBlocks("cosmoshub-4", "cosmosvalcons123", 5)
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	const want = `sl_exporter_cosmos_val_consecutive_missed_blocks{address="cosmosvalcons123",chain_id="cosmoshub-4"} 5`
+	require.Contains(t, r.Body.String(), want)
+}
+
 func TestCosmos_SetValSignedBlock(t *testing.T) {
 	t.Parallel()
 
 	metrics := NewCosmos()
 	reg := prometheus.NewRegistry()
-	reg.MustRegister(metrics.Metrics()[3])
+	reg.MustRegister(metrics.Metrics()...)
 	h := metricsHandler(reg)
 
 	metrics.SetValSignedBlock("cosmoshub-4", "cosmosvalcons123", 12345)
@@ -96,7 +179,7 @@ func TestCosmos_SetValMissedBlocks(t *testing.T) {
 
 	metrics := NewCosmos()
 	reg := prometheus.NewRegistry()
-	reg.MustRegister(metrics.Metrics()[4])
+	reg.MustRegister(metrics.Metrics()...)
 	h := metricsHandler(reg)
 
 	metrics.SetValMissedBlocks("cosmoshub-4", "cosmosvalcons123", 9)
@@ -108,20 +191,70 @@ func TestCosmos_SetValMissedBlocks(t *testing.T) {
 	require.Contains(t, r.Body.String(), want)
 }
 
+func TestCosmos_DeleteValMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	metrics.IncValSignedBlocks("cosmoshub-4", "cosmosvalcons123")
+	metrics.SetValSignedBlock("cosmoshub-4", "cosmosvalcons123", 12345)
+	metrics.SetValSignedBlock("cosmoshub-4", "cosmosvalcons456", 12345)
+	metrics.DeleteValMetrics("cosmoshub-4", "cosmosvalcons123")
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.NotContains(t, r.Body.String(), `address="cosmosvalcons123"`)
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_val_signed_block_height{address="cosmosvalcons456",chain_id="cosmoshub-4"} 12345`)
+}
+
 func TestCosmos_SetValSlashingParams(t *testing.T) {
 	t.Parallel()
 
 	metrics := NewCosmos()
 	reg := prometheus.NewRegistry()
-	reg.MustRegister(metrics.Metrics()[5])
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	var params cosmos.SlashingParams
+	params.Params.SignedBlocksWindow = "100"
+	params.Params.MinSignedPerWindow = "0.050000000000000000"
+	params.Params.DowntimeJailDuration = "600s"
+	params.Params.SlashFractionDoubleSign = "0.050000000000000000"
+	params.Params.SlashFractionDowntime = "0.000100000000000000"
+	metrics.SetValSlashingParams("cosmoshub-4", params)
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	for _, want := range []string{
+		`sl_exporter_cosmos_val_slashing_window_blocks{chain_id="cosmoshub-4"} 100`,
+		`sl_exporter_cosmos_val_min_signed_per_window_ratio{chain_id="cosmoshub-4"} 0.05`,
+		`sl_exporter_cosmos_val_downtime_jail_duration_seconds{chain_id="cosmoshub-4"} 600`,
+		`sl_exporter_cosmos_val_slash_fraction_double_sign_ratio{chain_id="cosmoshub-4"} 0.05`,
+		`sl_exporter_cosmos_val_slash_fraction_downtime_ratio{chain_id="cosmoshub-4"} 0.0001`,
+	} {
+		require.Contains(t, r.Body.String(), want)
+	}
+}
+
+func TestCosmos_SetValMissedBlocksUntilJail(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
 	h := metricsHandler(reg)
 
-	metrics.SetValSlashingParams("cosmoshub-4", 100)
+	metrics.SetValMissedBlocksUntilJail("cosmoshub-4", "cosmosvalcons123", 500)
 
 	r := httptest.NewRecorder()
 	h.ServeHTTP(r, stubRequest)
 
-	const want = `sl_exporter_cosmos_val_slashing_window_blocks{chain_id="cosmoshub-4"} 100`
+	const want = `sl_exporter_cosmos_val_missed_blocks_until_jail{address="cosmosvalcons123",chain_id="cosmoshub-4"} 500`
 	require.Contains(t, r.Body.String(), want)
 }
 
@@ -130,14 +263,263 @@ func TestCosmos_SetAccountBalance(t *testing.T) {
 
 	reg := prometheus.NewRegistry()
 	metrics := NewCosmos()
-	reg.MustRegister(metrics.Metrics()[6])
+	reg.MustRegister(metrics.Metrics()...)
 
-	metrics.SetAccountBalance("cosmoshub-4", "cosmoshub", "cosmos123", "uatom", 56789)
+	metrics.SetAccountBalance("cosmoshub-4", "cosmoshub", cosmos.AccountBalance{
+		Account: "cosmos123", Denom: "uatom", Amount: 56789, Metadata: cosmos.DenomMetadata{Base: "uatom"},
+	})
 
 	h := metricsHandler(reg)
 	r := httptest.NewRecorder()
 	h.ServeHTTP(r, stubRequest)
 
-	const want = `sl_exporter_cosmos_account_balance{address="cosmos123",alias="cosmoshub",chain_id="cosmoshub-4",denom="uatom"} 56789`
+	const want = `sl_exporter_cosmos_account_balance{address="cosmos123",alias="cosmoshub",base_denom="uatom",chain_id="cosmoshub-4",denom="uatom",display_denom=""} 56789`
 	require.Contains(t, r.Body.String(), want)
 }
+
+func TestCosmos_SetAccountBalance_Display(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	metrics := NewCosmos()
+	reg.MustRegister(metrics.Metrics()...)
+
+	metrics.SetAccountBalance("osmosis-1", "osmosis", cosmos.AccountBalance{
+		Account: "osmo123", Denom: "ibc/ABC", Amount: 1500000,
+	})
+	metrics.SetAccountBalance("osmosis-1", "osmosis", cosmos.AccountBalance{
+		Account: "osmo123", Denom: "ibc/ABC", Amount: 1500000,
+		Metadata: cosmos.DenomMetadata{Base: "uatom", Display: "atom", Exponent: 6},
+	})
+
+	h := metricsHandler(reg)
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_account_balance{address="osmo123",alias="osmosis",base_denom="uatom",chain_id="osmosis-1",denom="ibc/ABC",display_denom="atom"} 1.5e+06`)
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_account_balance_display{address="osmo123",alias="osmosis",base_denom="uatom",chain_id="osmosis-1",denom="ibc/ABC",display_denom="atom"} 1.5`)
+	// The series without metadata is replaced.
+	require.NotContains(t, r.Body.String(), `base_denom=""`)
+}
+
+func TestCosmos_SetAccountBalances(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	metrics := NewCosmos()
+	reg.MustRegister(metrics.Metrics()...)
+
+	metrics.SetAccountBalance("cosmoshub-4", "other", cosmos.AccountBalance{Account: "cosmos456", Denom: "uatom", Amount: 1})
+	metrics.SetAccountBalances("cosmoshub-4", "cosmoshub", "cosmos123", []cosmos.AccountBalance{
+		{Account: "cosmos123", Denom: "uatom", Amount: 56789},
+		{Account: "cosmos123", Denom: "ibc/ABC", Amount: 10, Metadata: cosmos.DenomMetadata{Base: "uatom", Display: "atom", Exponent: 6}},
+	})
+	metrics.SetAccountBalances("cosmoshub-4", "cosmoshub", "cosmos123", []cosmos.AccountBalance{
+		{Account: "cosmos123", Denom: "uatom", Amount: 56790},
+	})
+
+	h := metricsHandler(reg)
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_account_balance{address="cosmos123",alias="cosmoshub",base_denom="",chain_id="cosmoshub-4",denom="uatom",display_denom=""} 56790`)
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_account_balance{address="cosmos456",alias="other",base_denom="",chain_id="cosmoshub-4",denom="uatom",display_denom=""} 1`)
+	require.NotContains(t, r.Body.String(), `ibc/ABC`)
+	require.NotContains(t, r.Body.String(), `sl_exporter_cosmos_account_balance_display`)
+}
+
+func TestCosmos_SetAccountStaking(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	completion := time.Unix(1700000000, 0)
+	staking := cosmos.AccountStaking{
+		BondDenom:   "uatom",
+		Delegations: []cosmos.Delegation{{Validator: "cosmosvaloper1", Denom: "uatom", Amount: 100}},
+		Unbondings: []cosmos.UnbondingEntry{
+			{Validator: "cosmosvaloper1", CreationHeight: 10, CompletionTime: completion, Amount: 5},
+			{Validator: "cosmosvaloper1", CreationHeight: 10, CompletionTime: completion, Amount: 2},
+		},
+		Redelegations: []cosmos.RedelegationEntry{
+			{SrcValidator: "cosmosvaloper1", DstValidator: "cosmosvaloper2", CreationHeight: 11, CompletionTime: completion, Amount: 3},
+		},
+		Rewards: []cosmos.DelegationReward{{Validator: "cosmosvaloper1", Denom: "uatom", Amount: 1.5}},
+	}
+	// Updating with the same entries does not add them again.
+	metrics.SetAccountStaking("cosmoshub-4", "treasury", "cosmos123", staking)
+	metrics.SetAccountStaking("cosmoshub-4", "treasury", "cosmos123", staking)
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	for _, want := range []string{
+		`sl_exporter_cosmos_account_delegated{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",denom="uatom",validator="cosmosvaloper1"} 100`,
+		`sl_exporter_cosmos_account_unbonding{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",creation_height="10",denom="uatom",validator="cosmosvaloper1"} 7`,
+		`sl_exporter_cosmos_account_unbonding_completion_time{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",creation_height="10",validator="cosmosvaloper1"} 1.7e+09`,
+		`sl_exporter_cosmos_account_redelegating{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",creation_height="11",denom="uatom",dst_validator="cosmosvaloper2",src_validator="cosmosvaloper1"} 3`,
+		`sl_exporter_cosmos_account_redelegation_completion_time{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",creation_height="11",dst_validator="cosmosvaloper2",src_validator="cosmosvaloper1"} 1.7e+09`,
+		`sl_exporter_cosmos_account_staking_rewards{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",denom="uatom",validator="cosmosvaloper1"} 1.5`,
+	} {
+		require.Contains(t, r.Body.String(), want)
+	}
+
+	// Only completed entries are removed.
+	staking.Redelegations = nil
+	metrics.SetAccountStaking("cosmoshub-4", "treasury", "cosmos123", staking)
+	r = httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.Contains(t, r.Body.String(), `sl_exporter_cosmos_account_unbonding{address="cosmos123",alias="treasury",chain_id="cosmoshub-4",creation_height="10",denom="uatom",validator="cosmosvaloper1"} 7`)
+	require.NotContains(t, r.Body.String(), "cosmosvaloper2")
+
+	metrics.SetAccountStaking("cosmoshub-4", "treasury", "cosmos123", cosmos.AccountStaking{BondDenom: "uatom"})
+	r = httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.NotContains(t, r.Body.String(), "cosmos123")
+}
+
+func TestCosmos_StakingMetrics(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	metrics.SetValBondStatus("cosmoshub-4", "cosmosvaloper123", cosmos.BondStatusBonded)
+	metrics.SetValTokens("cosmoshub-4", "cosmosvaloper123", 1000)
+	metrics.SetValDelegatorShares("cosmoshub-4", "cosmosvaloper123", 1001)
+	metrics.SetValCommission("cosmoshub-4", "cosmosvaloper123", 0.05, 0.2)
+	metrics.SetValRank("cosmoshub-4", "cosmosvaloper123", 7)
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	for _, want := range []string{
+		`sl_exporter_cosmos_val_bond_status{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} 3`,
+		`sl_exporter_cosmos_val_tokens{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} 1000`,
+		`sl_exporter_cosmos_val_delegator_shares{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} 1001`,
+		`sl_exporter_cosmos_val_commission_rate_ratio{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} 0.05`,
+		`sl_exporter_cosmos_val_commission_max_rate_ratio{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} 0.2`,
+		`sl_exporter_cosmos_val_voting_power_rank{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} 7`,
+	} {
+		require.Contains(t, r.Body.String(), want)
+	}
+}
+
+func TestCosmos_SetValConsAddressMismatch(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	for _, tt := range []struct {
+		Mismatch  bool
+		WantValue int
+	}{
+		{true, 1},
+		{false, 0},
+	} {
+		metrics.SetValConsAddressMismatch("cosmoshub-4", "cosmosvaloper123", tt.Mismatch)
+		r := httptest.NewRecorder()
+		h.ServeHTTP(r, stubRequest)
+
+		want := fmt.Sprintf(`sl_exporter_cosmos_val_consensus_address_mismatch{chain_id="cosmoshub-4",valoper="cosmosvaloper123"} %d`, tt.WantValue)
+		require.Contains(t, r.Body.String(), want, tt)
+	}
+}
+
+func TestCosmos_SetGovProposals(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	end := time.Unix(1700000000, 0)
+	proposals := []cosmos.Proposal{
+		{ID: 1, Title: "Upgrade", VotingEndTime: end},
+		{ID: 2, Title: "Spend", VotingEndTime: end},
+	}
+	votes := map[uint64][]cosmos.ProposalVote{
+		1: {
+			{Voter: cosmos.Voter{Address: "cosmos1", Alias: "val"}, Voted: true},
+			{Voter: cosmos.Voter{Address: "cosmos2", Alias: "treasury"}, Voted: false},
+		},
+	}
+	metrics.SetGovProposals("cosmoshub-4", proposals, votes)
+	metrics.SetGovProposals("osmosis-1", proposals[:1], nil)
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	for _, want := range []string{
+		`sl_exporter_cosmos_gov_proposal_voting_end_timestamp_seconds{chain_id="cosmoshub-4",proposal_id="1",title="Upgrade"} 1.7e+09`,
+		`sl_exporter_cosmos_gov_proposal_voting_end_timestamp_seconds{chain_id="cosmoshub-4",proposal_id="2",title="Spend"} 1.7e+09`,
+		`sl_exporter_cosmos_gov_proposal_voting_remaining_seconds{chain_id="cosmoshub-4",proposal_id="1"} -`,
+		`sl_exporter_cosmos_gov_proposal_voted{address="cosmos1",alias="val",chain_id="cosmoshub-4",proposal_id="1"} 1`,
+		`sl_exporter_cosmos_gov_proposal_voted{address="cosmos2",alias="treasury",chain_id="cosmoshub-4",proposal_id="1"} 0`,
+		`sl_exporter_cosmos_gov_proposal_voting_end_timestamp_seconds{chain_id="osmosis-1",proposal_id="1",title="Upgrade"} 1.7e+09`,
+	} {
+		require.Contains(t, r.Body.String(), want)
+	}
+
+	// Proposals no longer in voting are removed.
+	metrics.SetGovProposals("cosmoshub-4", proposals[1:], nil)
+
+	r = httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.NotContains(t, r.Body.String(), `chain_id="cosmoshub-4",proposal_id="1"`)
+	require.NotContains(t, r.Body.String(), `sl_exporter_cosmos_gov_proposal_voted`)
+	require.Contains(t, r.Body.String(), `chain_id="cosmoshub-4",proposal_id="2"`)
+	require.Contains(t, r.Body.String(), `chain_id="osmosis-1",proposal_id="1"`)
+
+	// A changed title replaces the series.
+	metrics.SetGovProposals("cosmoshub-4", []cosmos.Proposal{{ID: 2, Title: "Community spend", VotingEndTime: end}}, nil)
+
+	r = httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.Contains(t, r.Body.String(), `title="Community spend"`)
+	require.NotContains(t, r.Body.String(), `title="Spend"`)
+}
+
+func TestCosmos_SetUpgradePlan(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewCosmos()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.Metrics()...)
+	h := metricsHandler(reg)
+
+	metrics.SetUpgradePlan("cosmoshub-4", "v14", 16000000, 120)
+	metrics.SetUpgradePlan("cosmoshub-4", "v15", 16500000, 3600)
+
+	r := httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	for _, want := range []string{
+		`sl_exporter_cosmos_upgrade_plan_info{chain_id="cosmoshub-4",name="v15"} 1`,
+		`sl_exporter_cosmos_upgrade_plan_height{chain_id="cosmoshub-4"} 1.65e+07`,
+		`sl_exporter_cosmos_upgrade_plan_estimated_remaining_seconds{chain_id="cosmoshub-4"} 3600`,
+	} {
+		require.Contains(t, r.Body.String(), want)
+	}
+	require.NotContains(t, r.Body.String(), `name="v14"`)
+
+	metrics.ClearUpgradePlan("cosmoshub-4")
+
+	r = httptest.NewRecorder()
+	h.ServeHTTP(r, stubRequest)
+
+	require.NotContains(t, r.Body.String(), `cosmoshub-4`)
+}
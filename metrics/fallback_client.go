@@ -1,90 +1,523 @@
 package metrics
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/exp/slog"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
+// FallbackClient sends GET requests to the first healthy host, falling back to the next host on failure.
+// Hosts that fail repeatedly are skipped for a cool-down period, then probed in the background and
+// re-admitted once the probe succeeds. Hosts marked as lagging are only tried after all other hosts.
+// Concurrent requests for the same path share a single request. See WithCache for caching responses.
 type FallbackClient struct {
-	hosts   []url.URL
-	httpDo  func(req *http.Request) (*http.Response, error)
-	log     *slog.Logger
-	metrics ClientMetrics
+	hosts    []fallbackHost
+	inflight *singleflight.Group
+	cache    *responseCache
+	httpDo   func(req *http.Request) (*http.Response, error)
+	log      *slog.Logger
+	metrics  ClientMetrics
+	chainID  string
+	cooldown time.Duration
+	retry    RetryPolicy
+}
+
+type fallbackHost struct {
+	url     url.URL
+	breaker *circuitBreaker
+	lagging *atomic.Bool
+	// limiter is nil if the host is not rate limited.
+	limiter *rate.Limiter
+	// header is added to every request, e.g. for authentication. Never log it.
+	header http.Header
+	// httpDo overrides the client's httpDo, e.g. for a host with its own TLS config.
+	httpDo func(req *http.Request) (*http.Response, error)
 }
 
 type ClientMetrics interface {
 	IncAPIError(host url.URL, reason string)
-	// TODO(nix): Metrics for request counts. Latency histogram.
+	// ObserveAPIRequest records a completed request. Code is the status code or, if there is no response,
+	// the error reason.
+	ObserveAPIRequest(host url.URL, pathTemplate, code string, latency time.Duration)
+	// SetCircuitState records the circuit breaker state of a host. The chain ID is empty unless set with WithChainID.
+	SetCircuitState(chainID string, host url.URL, state CircuitState)
+	// AddThrottledTime records time spent waiting for the client-side rate limit of a host.
+	AddThrottledTime(host url.URL, d time.Duration)
+	// IncCoalescedRequest records a request that shared the response of an identical in-flight request.
+	IncCoalescedRequest(pathTemplate string)
+	// SetCacheStaleness records the age of a cached response served because all hosts failed.
+	// Zero means the latest response is fresh. The chain ID is empty unless set with WithChainID.
+	SetCacheStaleness(chainID, pathTemplate string, seconds float64)
+}
+
+// defaultCircuitCooldown is how long a host is skipped after its circuit opens, before it is probed.
+const defaultCircuitCooldown = 30 * time.Second
+
+// probeTimeout limits background probes of unhealthy hosts.
+const probeTimeout = 10 * time.Second
+
+// FallbackOption customizes a FallbackClient.
+type FallbackOption func(*FallbackClient)
+
+// WithChainID labels the client's per-chain metrics, such as the circuit state of its hosts and the staleness of
+// its cache, with the chain ID.
+func WithChainID(chainID string) FallbackOption {
+	return func(c *FallbackClient) { c.chainID = chainID }
+}
+
+// WithRetry retries transient failures, such as 429 and 503 responses, before falling back to the next host.
+func WithRetry(policy RetryPolicy) FallbackOption {
+	return func(c *FallbackClient) { c.retry = policy }
+}
+
+// WithRateLimit limits requests to the host to requestsPerSecond, allowing bursts of up to burst requests.
+// If burst is not positive, it defaults to requestsPerSecond rounded up.
+// Requests wait for the rate limit unless the context deadline would pass first, in which case the next host is tried.
+func WithRateLimit(host url.URL, requestsPerSecond float64, burst int) FallbackOption {
+	return func(c *FallbackClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				c.hosts[i].limiter = newRateLimiter(requestsPerSecond, burst)
+			}
+		}
+	}
 }
 
-func NewFallbackClient(client *http.Client, metrics ClientMetrics, hosts []url.URL) *FallbackClient {
+// WithHeader adds the header to every request to the host, e.g. an API key or Authorization header.
+func WithHeader(host url.URL, header http.Header) FallbackOption {
+	return func(c *FallbackClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				c.hosts[i].header = header.Clone()
+			}
+		}
+	}
+}
+
+// WithHTTPClient sends requests to the host with its own client instead of the default client,
+// e.g. for a private CA or mutual TLS.
+func WithHTTPClient(host url.URL, client *http.Client) FallbackOption {
+	return func(c *FallbackClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				c.hosts[i].httpDo = client.Do
+			}
+		}
+	}
+}
+
+// WithCache caches responses of paths with a TTL. Cached responses are served without a request until they expire.
+// Expired responses are revalidated if the host sent an ETag, and served stale if all hosts fail.
+func WithCache(ttls CacheTTLs) FallbackOption {
+	return func(c *FallbackClient) { c.cache = newResponseCache(ttls) }
+}
+
+func NewFallbackClient(client *http.Client, metrics ClientMetrics, hosts []url.URL, opts ...FallbackOption) *FallbackClient {
 	if len(hosts) == 0 {
 		panic("no hosts provided")
 	}
-	return &FallbackClient{
-		hosts:   hosts,
-		httpDo:  client.Do,
-		log:     slog.Default(),
-		metrics: metrics,
+	fallbackHosts := make([]fallbackHost, len(hosts))
+	for i, host := range hosts {
+		fallbackHosts[i] = fallbackHost{
+			url:     host,
+			lagging: new(atomic.Bool),
+		}
 	}
+	c := &FallbackClient{
+		hosts:    fallbackHosts,
+		inflight: new(singleflight.Group),
+		httpDo:   client.Do,
+		log:      slog.Default(),
+		metrics:  metrics,
+		cooldown: defaultCircuitCooldown,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	// Breakers record their initial state, so they are created once options have set the chain ID.
+	for i := range c.hosts {
+		host := c.hosts[i].url
+		c.hosts[i].breaker = newCircuitBreaker(func(state CircuitState) { metrics.SetCircuitState(c.chainID, host, state) })
+	}
+	return c
 }
 
 const unknownErrReason = "unknown"
 
+// ErrNoHealthyHosts is returned when the circuit of every host is open.
+var ErrNoHealthyHosts = errors.New("no healthy hosts")
+
+// StatusError is returned when all hosts fail and the last host responded with a non-2xx status code.
+type StatusError struct {
+	// URL is redacted, so it is safe to log.
+	URL  string
+	Code int
+	// RetryAfter is parsed from the Retry-After header, if any.
+	RetryAfter time.Duration
+	// Body is the start of the response body, e.g. the error returned by the Cosmos SDK.
+	Body []byte
+}
+
+func (e StatusError) Error() string { return fmt.Sprintf("%s: bad status code %d", e.URL, e.Code) }
+
+// StatusCode returns the HTTP status code.
+func (e StatusError) StatusCode() int { return e.Code }
+
+// NotFound returns true if the Cosmos SDK responded that the resource does not exist, e.g. a vote that was
+// never cast. Depending on the SDK version and module, the gRPC status code in the body is NotFound or
+// InvalidArgument, so the message is matched as well. A 404 without a status body means the host does not
+// serve the path at all, e.g. a misconfigured proxy, so it is not a not found.
+func (e StatusError) NotFound() bool {
+	if e.Code != http.StatusBadRequest && e.Code != http.StatusNotFound {
+		return false
+	}
+	var status struct {
+		Code    *int   `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(e.Body, &status); err != nil || status.Code == nil {
+		return false
+	}
+	return *status.Code == grpcNotFound || strings.Contains(status.Message, "not found")
+}
+
+// maxErrorBodySize limits how much of an unsuccessful response body is kept in a StatusError.
+const maxErrorBodySize = 4 << 10
+
+// sharedRequestTimeout limits a request shared by concurrent callers if the caller that started it has no deadline.
+const sharedRequestTimeout = 30 * time.Second
+
+// Get sends a GET request for the path, e.g. /cosmos/base/tendermint/v1beta1/blocks/latest, to the hosts.
+// If an identical request is in flight, Get waits for and returns a copy of its response instead.
+// Each caller stops waiting when its context is done.
 func (c FallbackClient) Get(ctx context.Context, path url.URL) (*http.Response, error) {
-	doGet := func(host url.URL) (*http.Response, error) {
-		log := c.log.With("host", host.Hostname(), "path", path, "method", http.MethodGet)
+	if resp, ok := c.cache.fresh(path); ok {
+		return resp.copy(), nil
+	}
 
-		host.Path = path.Path
-		host.RawQuery = path.RawQuery
+	var leader bool
+	ch := c.inflight.DoChan(path.String(), func() (any, error) {
+		leader = true
+		// Detached, so waiting callers are not failed when the caller that started the request gives up.
+		// The request keeps that caller's time budget, so rate limits and retries still give up in time.
+		timeout := sharedRequestTimeout
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		sharedCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		return c.fetch(sharedCtx, path)
+	})
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, host.String(), nil)
-		if err != nil {
-			log.Debug("Failed to create request", "error", err)
-			c.recordErrMetric(host, err)
-			return nil, err
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if !leader {
+			c.metrics.IncCoalescedRequest(templatePath(path.Path))
+		}
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(sharedResponse).copy(), nil
+	}
+}
+
+// fetch requests the path from the hosts and caches the response. If all hosts fail, a cached response is
+// served regardless of its age.
+func (c FallbackClient) fetch(ctx context.Context, path url.URL) (sharedResponse, error) {
+	pathTemplate := templatePath(path.Path)
+	entry, cached := c.cache.lookup(path)
+
+	resp, host, err := c.get(ctx, path, entry.validator)
+	if err != nil {
+		if cached && isHostFailure(err) {
+			age := c.cache.age(entry)
+			c.log.Warn("All hosts failed, serving stale response", "path", path.Path, "age", age, "error", err)
+			c.metrics.SetCacheStaleness(c.chainID, pathTemplate, age.Seconds())
+			return entry.response, nil
+		}
+		return sharedResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		c.cache.refresh(path, entry, resp.Header)
+		c.metrics.SetCacheStaleness(c.chainID, pathTemplate, 0)
+		return entry.response, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return sharedResponse{}, fmt.Errorf("read body: %w", err)
+	}
+	shared := sharedResponse{resp: resp, body: body}
+	if c.cache.store(path, host, shared) {
+		c.metrics.SetCacheStaleness(c.chainID, pathTemplate, 0)
+	}
+	return shared, nil
+}
+
+// sharedResponse is a response with its body read, so it can be returned to multiple callers.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+func (r sharedResponse) copy() *http.Response {
+	resp := *r.resp
+	resp.Body = io.NopCloser(bytes.NewReader(r.body))
+	return &resp
+}
+
+// get returns the first successful response and the host that sent it. If validator is not nil, the response
+// from the validator's host may be 304 Not Modified.
+func (c FallbackClient) get(ctx context.Context, path url.URL, validator *cacheValidator) (*http.Response, url.URL, error) {
+	lastErr := ErrNoHealthyHosts
+	// Lagging hosts are a last resort. Stale data is better than no data.
+	for _, lagging := range []bool{false, true} {
+		for _, host := range c.hosts {
+			if host.lagging.Load() != lagging || !host.breaker.allow() {
+				continue
+			}
+			resp, err := c.doGetWithRetry(ctx, host, path, validator)
+			if isNotFound(err) {
+				// Other hosts would give the same answer.
+				return nil, url.URL{}, err
+			}
+			if err != nil {
+				c.recordHealth(host, path, err)
+				lastErr = err
+				continue
+			}
+			host.breaker.success()
+			return resp, host.url, nil
+		}
+	}
+	return nil, url.URL{}, lastErr
+}
+
+// Hosts returns all hosts in order of preference.
+func (c FallbackClient) Hosts() []url.URL {
+	hosts := make([]url.URL, len(c.hosts))
+	for i, host := range c.hosts {
+		hosts[i] = host.url
+	}
+	return hosts
+}
+
+// GetHost sends a GET request to a single host without falling back to other hosts, regardless of the host's health.
+func (c FallbackClient) GetHost(ctx context.Context, host url.URL, path url.URL) (*http.Response, error) {
+	h, ok := c.findHost(host)
+	if !ok {
+		return nil, fmt.Errorf("unknown host %s", host.Hostname())
+	}
+	return c.doGet(ctx, h, path, nil)
+}
+
+// SetLagging marks a host as lagging behind the other hosts, e.g. when its latest block height is behind
+// the chain tip. Lagging hosts are only tried after all other hosts fail.
+func (c FallbackClient) SetLagging(host url.URL, lagging bool) {
+	if h, ok := c.findHost(host); ok {
+		h.lagging.Store(lagging)
+	}
+}
+
+func (c FallbackClient) findHost(host url.URL) (fallbackHost, bool) {
+	for _, h := range c.hosts {
+		if h.url == host {
+			return h, true
+		}
+	}
+	return fallbackHost{}, false
+}
+
+func (c FallbackClient) doGet(ctx context.Context, fallback fallbackHost, path url.URL, validator *cacheValidator) (*http.Response, error) {
+	host := fallback.url
+	log := c.log.With("host", host.Hostname(), "path", path, "method", http.MethodGet)
+
+	if fallback.limiter != nil {
+		throttled, err := throttle(ctx, fallback.limiter)
+		if throttled > 0 {
+			c.metrics.AddThrottledTime(host, throttled)
 		}
-		resp, err := c.httpDo(req)
 		if err != nil {
-			log.Debug("Failed request", "error", err)
+			log.Debug("Request not sent", "error", err)
 			c.recordErrMetric(host, err)
 			return nil, err
 		}
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			_ = resp.Body.Close()
+	}
+
+	host.Path = path.Path
+	host.RawQuery = path.RawQuery
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host.String(), nil)
+	if err != nil {
+		log.Debug("Failed to create request", "error", err)
+		c.recordErrMetric(host, err)
+		return nil, err
+	}
+	for k, v := range fallback.header {
+		req.Header[k] = v
+	}
+	conditional := validator != nil && validator.host == fallback.url
+	if conditional {
+		req.Header.Set("If-None-Match", validator.etag)
+	}
+	pathTemplate := templatePath(path.Path)
+	start := time.Now()
+	httpDo := c.httpDo
+	if fallback.httpDo != nil {
+		httpDo = fallback.httpDo
+	}
+	resp, err := httpDo(req)
+	if err != nil {
+		log.Debug("Failed request", "error", err)
+		if reason, ok := errReason(err); ok {
+			c.metrics.ObserveAPIRequest(host, pathTemplate, reason, time.Since(start))
+		}
+		c.recordErrMetric(host, err)
+		return nil, err
+	}
+	c.metrics.ObserveAPIRequest(host, pathTemplate, strconv.Itoa(resp.StatusCode), time.Since(start))
+	if conditional && resp.StatusCode == http.StatusNotModified {
+		return resp, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		_ = resp.Body.Close()
+		statusErr := StatusError{
+			URL:        req.URL.Redacted(),
+			Code:       resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Body:       body,
+		}
+		// A resource that does not exist is an answer, not an API error.
+		if !statusErr.NotFound() {
 			log.Debug("Response returned bad status code", "status", resp.StatusCode)
 			c.metrics.IncAPIError(host, strconv.Itoa(resp.StatusCode))
-			return nil, fmt.Errorf("%s: bad status code %d", req.URL, resp.StatusCode)
 		}
-		return resp, nil
+		return nil, statusErr
 	}
+	return resp, nil
+}
+
+// doGetWithRetry retries transient failures according to the retry policy. Gives up early if the context
+// deadline would pass before the next attempt.
+func (c FallbackClient) doGetWithRetry(ctx context.Context, fallback fallbackHost, path url.URL, validator *cacheValidator) (*http.Response, error) {
+	host := fallback.url
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doGet(ctx, fallback, path, validator)
+		if err == nil || attempt >= c.retry.maxAttempts() || !isRetryable(err) {
+			return resp, err
+		}
+		var statusErr StatusError
+		errors.As(err, &statusErr)
+		delay := c.retry.delay(attempt, statusErr.RetryAfter)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+		c.metrics.IncAPIError(host, "retry")
+		c.log.Debug("Retrying request", "host", host.Hostname(), "path", path, "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// recordHealth records a failed request against the host's circuit. If the circuit opens, the host is probed
+// in the background with the same path after the cool-down.
+func (c FallbackClient) recordHealth(host fallbackHost, path url.URL, err error) {
+	if !isHostFailure(err) {
+		return
+	}
+	if host.breaker.failure() {
+		c.log.Warn("Host failed repeatedly, skipping until healthy", "host", host.url.Hostname(), "cooldown", c.cooldown, "error", err)
+		c.scheduleProbe(host, path)
+	}
+}
 
-	var lastErr error
-	for _, host := range c.hosts {
-		resp, err := doGet(host)
+func (c FallbackClient) scheduleProbe(host fallbackHost, path url.URL) {
+	time.AfterFunc(c.cooldown, func() {
+		if !host.breaker.probe() {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		defer cancel()
+		resp, err := c.doGet(ctx, host, path, nil)
 		if err != nil {
-			lastErr = err
-			continue
+			c.recordHealth(host, path, err)
+			return
 		}
-		return resp, nil
+		_ = resp.Body.Close()
+		host.breaker.success()
+		c.log.Info("Host is healthy again", "host", host.url.Hostname())
+	})
+}
+
+// isHostFailure returns true if the error indicates the host is unhealthy, as opposed to a bad request.
+func isHostFailure(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		// The request was never sent.
+		return false
 	}
-	return nil, lastErr
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusTooManyRequests || statusErr.Code >= 500
+	}
+	// The request was canceled by the caller, e.g. on shutdown, so the host may be fine.
+	return !errors.Is(err, context.Canceled)
+}
+
+// grpcNotFound is the gRPC status code the Cosmos SDK REST API returns in the body of a response for a
+// resource that does not exist.
+const grpcNotFound = 5
+
+// isNotFound returns true if a host responded that the resource does not exist. See StatusError.NotFound.
+func isNotFound(err error) bool {
+	var statusErr StatusError
+	return errors.As(err, &statusErr) && statusErr.NotFound()
 }
 
 func (c FallbackClient) recordErrMetric(host url.URL, err error) {
-	reason := unknownErrReason
+	if reason, ok := errReason(err); ok {
+		c.metrics.IncAPIError(host, reason)
+	}
+}
+
+// errReason returns the metric reason for a request error. Returns false if the error should not be recorded.
+func errReason(err error) (string, bool) {
 	switch {
-	case errors.Is(err, context.DeadlineExceeded):
-		reason = "timeout"
 	case errors.Is(err, context.Canceled):
 		// Do not record when the process is shutting down.
-		return
+		return "", false
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited", true
+	case isProxyError(err):
+		// Before timeout, so a timed out connection to the proxy is not mistaken for a slow upstream host.
+		return "proxy", true
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout", true
+	}
+	if reason, ok := connErrReason(err); ok {
+		return reason, true
 	}
-	c.metrics.IncAPIError(host, reason)
+	return unknownErrReason, true
 }
@@ -7,7 +7,11 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +23,33 @@ type mockClientMetrics struct {
 	IncClientErrCalls int
 	GotHost           url.URL
 	GotErrMsg         string
+
+	GotRequests []string
+	GotStates   []CircuitState
+	GotChainID  string
+
+	GotThrottled  time.Duration
+	GotCoalesced  atomic.Int64
+	GotStaleness  float64
+	GotStaleChain string
+}
+
+func (m *mockClientMetrics) SetCacheStaleness(chainID, pathTemplate string, seconds float64) {
+	m.GotStaleChain = chainID
+	m.GotStaleness = seconds
+}
+
+func (m *mockClientMetrics) IncCoalescedRequest(pathTemplate string) {
+	m.GotCoalesced.Add(1)
+}
+
+func (m *mockClientMetrics) AddThrottledTime(host url.URL, d time.Duration) {
+	m.GotThrottled += d
+}
+
+func (m *mockClientMetrics) SetCircuitState(chainID string, host url.URL, state CircuitState) {
+	m.GotChainID = chainID
+	m.GotStates = append(m.GotStates, state)
 }
 
 func (m *mockClientMetrics) IncAPIError(host url.URL, errMsg string) {
@@ -27,6 +58,10 @@ func (m *mockClientMetrics) IncAPIError(host url.URL, errMsg string) {
 	m.GotErrMsg = errMsg
 }
 
+func (m *mockClientMetrics) ObserveAPIRequest(host url.URL, pathTemplate, code string, latency time.Duration) {
+	m.GotRequests = append(m.GotRequests, host.Hostname()+" "+pathTemplate+" "+code)
+}
+
 var nopLogger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
 
 func TestFallbackClient_Get(t *testing.T) {
@@ -39,7 +74,8 @@ func TestFallbackClient_Get(t *testing.T) {
 	ctx := context.WithValue(context.Background(), dummy("test"), dummy("test"))
 
 	t.Run("happy path", func(t *testing.T) {
-		client := NewFallbackClient(&http.Client{}, nil, urls)
+		var metrics mockClientMetrics
+		client := NewFallbackClient(&http.Client{}, &metrics, urls)
 		client.log = nopLogger
 		require.NotNil(t, client.httpDo)
 
@@ -47,7 +83,10 @@ func TestFallbackClient_Get(t *testing.T) {
 		stubResp := &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
 		client.httpDo = func(req *http.Request) (*http.Response, error) {
 			callCount++
-			require.Same(t, ctx, req.Context())
+			// The request runs on a context detached from the caller, but with the caller's values.
+			require.Equal(t, dummy("test"), req.Context().Value(dummy("test")))
+			_, ok := req.Context().Deadline()
+			require.True(t, ok)
 			require.Equal(t, "GET", req.Method)
 			require.Equal(t, "http://1.example.com/v1/foo", req.URL.String())
 			return stubResp, nil
@@ -57,8 +96,9 @@ func TestFallbackClient_Get(t *testing.T) {
 		require.NoError(t, resp.Body.Close())
 
 		require.NoError(t, err)
-		require.Same(t, stubResp, resp)
+		require.Equal(t, stubResp.StatusCode, resp.StatusCode)
 		require.Equal(t, 1, callCount)
+		require.Equal(t, []string{"1.example.com /v1/foo 200"}, metrics.GotRequests)
 	})
 
 	t.Run("fallback on error", func(t *testing.T) {
@@ -73,7 +113,10 @@ func TestFallbackClient_Get(t *testing.T) {
 			if callCount == 1 {
 				return nil, errors.New("boom")
 			}
-			require.Same(t, ctx, req.Context())
+			// The request runs on a context detached from the caller, but with the caller's values.
+			require.Equal(t, dummy("test"), req.Context().Value(dummy("test")))
+			_, ok := req.Context().Deadline()
+			require.True(t, ok)
 			require.Equal(t, "GET", req.Method)
 			require.Equal(t, "http://2.example.com/v1/foo", req.URL.String())
 			return stubResp, nil
@@ -83,8 +126,9 @@ func TestFallbackClient_Get(t *testing.T) {
 		require.NoError(t, resp.Body.Close())
 
 		require.NoError(t, err)
-		require.Same(t, stubResp, resp)
+		require.Equal(t, stubResp.StatusCode, resp.StatusCode)
 		require.Equal(t, 2, callCount)
+		require.Equal(t, []string{"1.example.com /v1/foo unknown", "2.example.com /v1/foo 200"}, metrics.GotRequests)
 	})
 
 	t.Run("fallback on bad status code", func(t *testing.T) {
@@ -110,8 +154,81 @@ func TestFallbackClient_Get(t *testing.T) {
 		require.NoError(t, resp.Body.Close())
 
 		require.NoError(t, err)
-		require.Same(t, stubResp, resp)
+		require.Equal(t, stubResp.StatusCode, resp.StatusCode)
 		require.Equal(t, 2, callCount)
+		require.Equal(t, []string{"1.example.com / 500", "2.example.com / 202"}, metrics.GotRequests)
+	})
+
+	t.Run("no fallback if resource not found", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls)
+		client.log = nopLogger
+
+		const body = `{"code":5,"message":"rpc error: code = NotFound desc = vote not found","details":[]}`
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
+
+		//nolint
+		_, err := client.Get(ctx, url.URL{})
+
+		var statusErr StatusError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, body, string(statusErr.Body))
+		require.Equal(t, 1, callCount)
+		require.Zero(t, metrics.IncClientErrCalls)
+	})
+
+	t.Run("no fallback if bad request says not found", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls)
+		client.log = nopLogger
+
+		// The SDK answers a vote query for an account that has not voted with InvalidArgument.
+		const body = `{"code":3,"message":"rpc error: code = InvalidArgument desc = voter: cosmos123 not found for proposal: 12: invalid request","details":[]}`
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
+
+		//nolint
+		_, err := client.Get(ctx, url.URL{})
+
+		var statusErr StatusError
+		require.ErrorAs(t, err, &statusErr)
+		require.True(t, statusErr.NotFound())
+		require.Equal(t, 1, callCount)
+		require.Zero(t, metrics.IncClientErrCalls)
+	})
+
+	t.Run("fallback if path not served", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls)
+		client.log = nopLogger
+
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Hostname() == "1.example.com" {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader("404 page not found")),
+				}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		resp, err := client.Get(ctx, url.URL{})
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, []string{"1.example.com / 404", "2.example.com / 200"}, metrics.GotRequests)
 	})
 
 	t.Run("all errors", func(t *testing.T) {
@@ -139,6 +256,11 @@ func TestFallbackClient_Get(t *testing.T) {
 		_, err := client.Get(ctx, url.URL{})
 
 		require.Error(t, err)
+
+		var statusErr StatusError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, "http://2.example.com", statusErr.URL)
+		require.GreaterOrEqual(t, statusErr.StatusCode(), 301)
 	})
 
 	t.Run("error metrics", func(t *testing.T) {
@@ -183,5 +305,657 @@ func TestFallbackClient_Get(t *testing.T) {
 		_, _ = client.Get(ctx, url.URL{})
 
 		require.Zero(t, metrics.IncClientErrCalls)
+		require.Empty(t, metrics.GotRequests)
+	})
+
+	t.Run("skips unhealthy hosts", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls, WithChainID("cosmoshub-4"))
+		client.log = nopLogger
+		client.cooldown = time.Hour
+
+		var hosts []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Hostname())
+			if req.URL.Hostname() == "1.example.com" {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		for i := 0; i < circuitFailureThreshold+2; i++ {
+			resp, err := client.Get(ctx, url.URL{Path: "/v1/foo"})
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}
+
+		require.Len(t, hosts, 2*circuitFailureThreshold+2)
+		require.Equal(t, []string{"2.example.com", "2.example.com"}, hosts[2*circuitFailureThreshold:])
+		require.Contains(t, metrics.GotStates, CircuitOpen)
+		require.Equal(t, "cosmoshub-4", metrics.GotChainID)
+	})
+
+	t.Run("client errors do not open circuit", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls[:1])
+		client.log = nopLogger
+
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}, nil
+		}
+
+		for i := 0; i < circuitFailureThreshold+1; i++ {
+			_, err := client.Get(ctx, url.URL{})
+			require.Error(t, err)
+		}
+
+		require.Equal(t, circuitFailureThreshold+1, callCount)
+		require.Equal(t, []CircuitState{CircuitClosed}, metrics.GotStates)
+	})
+
+	t.Run("all hosts unhealthy", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls[:1])
+		client.log = nopLogger
+		client.cooldown = time.Hour
+
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}
+
+		for i := 0; i < circuitFailureThreshold; i++ {
+			_, err := client.Get(ctx, url.URL{})
+			require.Error(t, err)
+		}
+
+		_, err := client.Get(ctx, url.URL{})
+		require.ErrorIs(t, err, ErrNoHealthyHosts)
+	})
+
+	t.Run("probe re-admits host", func(t *testing.T) {
+		client := NewFallbackClient(nil, &mockClientMetrics{}, urls[:1])
+		client.log = nopLogger
+		client.cooldown = time.Millisecond
+
+		healthy := make(chan struct{})
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			select {
+			case <-healthy:
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			default:
+				return nil, errors.New("connection refused")
+			}
+		}
+
+		for i := 0; i < circuitFailureThreshold; i++ {
+			_, err := client.Get(ctx, url.URL{Path: "/v1/foo"})
+			require.Error(t, err)
+		}
+		require.False(t, client.hosts[0].breaker.allow())
+
+		close(healthy)
+		require.Eventually(t, client.hosts[0].breaker.allow, 5*time.Second, time.Millisecond)
+
+		resp, err := client.Get(ctx, url.URL{Path: "/v1/foo"})
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("lagging hosts are a last resort", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls)
+		client.log = nopLogger
+
+		var hosts []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Hostname())
+			return nil, errors.New("boom")
+		}
+
+		client.SetLagging(urls[0], true)
+		_, err := client.Get(ctx, url.URL{})
+		require.Error(t, err)
+		require.Equal(t, []string{"2.example.com", "1.example.com"}, hosts)
+
+		hosts = nil
+		client.SetLagging(urls[0], false)
+		_, err = client.Get(ctx, url.URL{})
+		require.Error(t, err)
+		require.Equal(t, []string{"1.example.com", "2.example.com"}, hosts)
+	})
+}
+
+func TestFallbackClient_Retry(t *testing.T) {
+	t.Parallel()
+
+	urls := []url.URL{
+		{Scheme: "http", Host: "1.example.com"},
+		{Scheme: "http", Host: "2.example.com"},
+	}
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls, WithRetry(policy))
+		client.log = nopLogger
+
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			require.Equal(t, "1.example.com", req.URL.Hostname())
+			if callCount < 3 {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		resp, err := client.Get(context.Background(), url.URL{})
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, 3, callCount)
+		require.Equal(t, []string{"1.example.com / 503", "1.example.com / 503", "1.example.com / 200"}, metrics.GotRequests)
 	})
+
+	t.Run("falls back after max attempts", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls, WithRetry(policy))
+		client.log = nopLogger
+
+		var hosts []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Hostname())
+			if req.URL.Hostname() == "1.example.com" {
+				return nil, errors.New("connection reset")
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		resp, err := client.Get(context.Background(), url.URL{})
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, []string{"1.example.com", "1.example.com", "1.example.com", "2.example.com"}, hosts)
+		// 3 failed requests and 2 retries.
+		require.Equal(t, 5, metrics.IncClientErrCalls)
+	})
+
+	t.Run("does not retry permanent failures", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls[:1], WithRetry(policy))
+		client.log = nopLogger
+
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}, nil
+		}
+
+		_, err := client.Get(context.Background(), url.URL{})
+		require.Error(t, err)
+		require.Equal(t, 1, callCount)
+		require.Equal(t, "404", metrics.GotErrMsg)
+	})
+
+	t.Run("honors retry after", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls[:1], WithRetry(policy))
+		client.log = nopLogger
+
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"60"}},
+				Body:       http.NoBody,
+			}, nil
+		}
+
+		// The deadline passes before the server allows another request, so give up immediately.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err := client.Get(ctx, url.URL{})
+
+		var statusErr StatusError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, time.Minute, statusErr.RetryAfter)
+		require.Equal(t, 1, callCount)
+		require.Equal(t, "429", metrics.GotErrMsg)
+	})
+}
+
+func TestFallbackClient_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	urls := []url.URL{
+		{Scheme: "http", Host: "1.example.com"},
+		{Scheme: "http", Host: "2.example.com"},
+	}
+
+	t.Run("waits for rate limit", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls, WithRateLimit(urls[0], 100, 1))
+		client.log = nopLogger
+
+		var hosts []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Hostname())
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(context.Background(), url.URL{})
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}
+
+		require.Equal(t, []string{"1.example.com", "1.example.com", "1.example.com"}, hosts)
+		require.Greater(t, metrics.GotThrottled, time.Duration(0))
+	})
+
+	t.Run("falls back if deadline is too soon", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls, WithRateLimit(urls[0], 0.1, 1))
+		client.log = nopLogger
+
+		var hosts []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Hostname())
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		for i := 0; i < circuitFailureThreshold+1; i++ {
+			resp, err := client.Get(ctx, url.URL{})
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}
+
+		require.Equal(t, "1.example.com", hosts[0])
+		require.NotContains(t, hosts[1:], "1.example.com")
+		require.Equal(t, "rate_limited", metrics.GotErrMsg)
+		// Throttling is not a host failure.
+		require.Equal(t, []CircuitState{CircuitClosed, CircuitClosed}, metrics.GotStates)
+	})
+}
+
+func TestFallbackClient_Coalesce(t *testing.T) {
+	t.Parallel()
+
+	var metrics mockClientMetrics
+	client := NewFallbackClient(nil, &metrics, []url.URL{{Scheme: "http", Host: "1.example.com"}})
+	client.log = nopLogger
+
+	const callers = 5
+	var (
+		callCount atomic.Int64
+		started   sync.WaitGroup
+		release   = make(chan struct{})
+	)
+	client.httpDo = func(req *http.Request) (*http.Response, error) {
+		callCount.Add(1)
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("shared"))}, nil
+	}
+
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	started.Add(callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			resp, err := client.Get(context.Background(), url.URL{Path: "/v1/foo"})
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			bodies[i] = string(b)
+		}()
+	}
+
+	// Give all callers time to join the in-flight request.
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Less(t, callCount.Load(), int64(callers))
+	require.Equal(t, int64(callers), callCount.Load()+metrics.GotCoalesced.Load())
+	require.Equal(t, []string{"shared", "shared", "shared", "shared", "shared"}, bodies)
+
+	// Requests for different paths are not coalesced.
+	calls := callCount.Load()
+	resp, err := client.Get(context.Background(), url.URL{Path: "/v1/bar"})
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, calls+1, callCount.Load())
+}
+
+func TestFallbackClient_CoalesceDetached(t *testing.T) {
+	t.Parallel()
+
+	var metrics mockClientMetrics
+	client := NewFallbackClient(nil, &metrics, []url.URL{{Scheme: "http", Host: "1.example.com"}})
+	client.log = nopLogger
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	client.httpDo = func(req *http.Request) (*http.Response, error) {
+		close(started)
+		<-release
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("shared"))}, nil
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.Get(leaderCtx, url.URL{Path: "/v1/foo"})
+		leaderErr <- err
+	}()
+	<-started
+
+	follower := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Get(context.Background(), url.URL{Path: "/v1/foo"})
+		require.NoError(t, err)
+		follower <- resp
+	}()
+	// Give the follower time to join the in-flight request.
+	time.Sleep(50 * time.Millisecond)
+
+	// The leader giving up does not fail the follower.
+	cancelLeader()
+	require.ErrorIs(t, <-leaderErr, context.Canceled)
+	close(release)
+
+	resp := <-follower
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "shared", string(b))
+	require.EqualValues(t, 1, metrics.GotCoalesced.Load())
+}
+
+func TestFallbackClient_Cache(t *testing.T) {
+	t.Parallel()
+
+	urls := []url.URL{
+		{Scheme: "http", Host: "1.example.com"},
+		{Scheme: "http", Host: "2.example.com"},
+	}
+	path := url.URL{Path: "/v1/params"}
+	readBody := func(t *testing.T, resp *http.Response) string {
+		t.Helper()
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("serves fresh responses", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewFallbackClient(nil, &mockClientMetrics{}, urls, WithCache(CacheTTLs{"/v1/params": time.Minute}))
+		client.log = nopLogger
+
+		var callCount int
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("params"))}, nil
+		}
+
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(context.Background(), path)
+			require.NoError(t, err)
+			require.Equal(t, "params", readBody(t, resp))
+		}
+		require.Equal(t, 1, callCount)
+
+		// Paths without a TTL are not cached.
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(context.Background(), url.URL{Path: "/v1/other"})
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}
+		require.Equal(t, 3, callCount)
+	})
+
+	t.Run("revalidates with etag", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewFallbackClient(nil, &mockClientMetrics{}, urls, WithCache(CacheTTLs{"/v1/params": time.Minute}))
+		client.log = nopLogger
+		now := time.Now()
+		client.cache.now = func() time.Time { return now }
+
+		var gotETags []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			etag := req.Header.Get("If-None-Match")
+			gotETags = append(gotETags, etag)
+			if etag == `"v1"` {
+				return &http.Response{StatusCode: http.StatusNotModified, Body: http.NoBody}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       io.NopCloser(strings.NewReader("params")),
+			}, nil
+		}
+
+		resp, err := client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.Equal(t, "params", readBody(t, resp))
+
+		now = now.Add(time.Minute)
+		resp, err = client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "params", readBody(t, resp))
+
+		// Fresh again after revalidation.
+		resp, err = client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, []string{"", `"v1"`}, gotETags)
+	})
+
+	t.Run("etag is not sent to other hosts", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewFallbackClient(nil, &mockClientMetrics{}, urls, WithCache(CacheTTLs{"/v1/params": time.Minute}))
+		client.log = nopLogger
+		now := time.Now()
+		client.cache.now = func() time.Time { return now }
+
+		var gotETags []string
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host == "1.example.com" && len(gotETags) > 0 {
+				return nil, errors.New("boom")
+			}
+			gotETags = append(gotETags, req.URL.Host+" "+req.Header.Get("If-None-Match"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       io.NopCloser(strings.NewReader("params")),
+			}, nil
+		}
+
+		resp, err := client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		now = now.Add(time.Minute)
+		resp, err = client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, []string{`1.example.com `, `2.example.com `}, gotETags)
+	})
+
+	t.Run("serves stale responses if all hosts fail", func(t *testing.T) {
+		t.Parallel()
+
+		var metrics mockClientMetrics
+		client := NewFallbackClient(nil, &metrics, urls, WithChainID("cosmoshub-4"), WithCache(CacheTTLs{"/v1/params": time.Minute}))
+		client.log = nopLogger
+		now := time.Now()
+		client.cache.now = func() time.Time { return now }
+
+		fail := false
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			if fail {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("params"))}, nil
+		}
+
+		resp, err := client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		fail = true
+		now = now.Add(5 * time.Minute)
+		resp, err = client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.Equal(t, "params", readBody(t, resp))
+		require.Equal(t, float64(300), metrics.GotStaleness)
+		require.Equal(t, "cosmoshub-4", metrics.GotStaleChain)
+
+		fail = false
+		resp, err = client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Zero(t, metrics.GotStaleness)
+	})
+
+	t.Run("does not serve stale responses for client errors", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewFallbackClient(nil, &mockClientMetrics{}, urls, WithCache(CacheTTLs{"/v1/params": time.Minute}))
+		client.log = nopLogger
+		now := time.Now()
+		client.cache.now = func() time.Time { return now }
+
+		status := http.StatusOK
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("params"))}, nil
+		}
+
+		resp, err := client.Get(context.Background(), path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		status = http.StatusNotFound
+		now = now.Add(time.Minute)
+		_, err = client.Get(context.Background(), path)
+		require.Error(t, err)
+	})
+}
+
+func TestFallbackClient_Credentials(t *testing.T) {
+	t.Parallel()
+
+	urls := []url.URL{
+		{Scheme: "http", Host: "1.example.com", User: url.UserPassword("user", "secret")},
+		{Scheme: "http", Host: "2.example.com"},
+	}
+	var metrics mockClientMetrics
+	client := NewFallbackClient(nil, &metrics, urls, WithHeader(urls[1], http.Header{"X-Api-Key": []string{"key"}}))
+	client.log = nopLogger
+
+	var headers []string
+	client.httpDo = func(req *http.Request) (*http.Response, error) {
+		headers = append(headers, req.Header.Get("X-Api-Key"))
+		return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}, nil
+	}
+
+	_, err := client.Get(context.Background(), url.URL{Path: "/v1/foo"})
+	require.Error(t, err)
+	require.Equal(t, []string{"", "key"}, headers)
+
+	_, err = client.GetHost(context.Background(), urls[0], url.URL{Path: "/v1/foo"})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "secret")
+	require.Equal(t, "1.example.com", metrics.GotHost.Hostname())
+}
+
+func TestFallbackClient_WithHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	var metrics mockClientMetrics
+	// The default client does not trust the test server's certificate.
+	client := NewFallbackClient(&http.Client{}, &metrics, []url.URL{*u})
+	client.log = nopLogger
+	_, err = client.Get(context.Background(), url.URL{})
+	require.Error(t, err)
+
+	client = NewFallbackClient(&http.Client{}, &metrics, []url.URL{*u}, WithHTTPClient(*u, server.Client()))
+	client.log = nopLogger
+	resp, err := client.Get(context.Background(), url.URL{})
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func TestFallbackClient_GetHost(t *testing.T) {
+	t.Parallel()
+
+	urls := []url.URL{
+		{Scheme: "http", Host: "1.example.com"},
+		{Scheme: "http", Host: "2.example.com"},
+	}
+	var metrics mockClientMetrics
+	client := NewFallbackClient(nil, &metrics, urls)
+	client.log = nopLogger
+	require.Equal(t, urls, client.Hosts())
+
+	client.httpDo = func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "http://2.example.com/v1/foo", req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}
+
+	resp, err := client.GetHost(context.Background(), urls[1], url.URL{Path: "/v1/foo"})
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	_, err = client.GetHost(context.Background(), url.URL{Host: "unknown.example.com"}, url.URL{})
+	require.Error(t, err)
+}
+
+func TestStatusError_NotFound(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Code int
+		Body string
+		Want bool
+	}{
+		// SDK v0.45 to v0.50
+		{http.StatusBadRequest, `{"code":3,"message":"rpc error: code = InvalidArgument desc = voter: cosmos123 not found for proposal: 12: invalid request","details":[]}`, true},
+		{http.StatusNotFound, `{"code":5,"message":"rpc error: code = NotFound desc = vote not found","details":[]}`, true},
+
+		{http.StatusBadRequest, `{"code":3,"message":"decoding bech32 failed: invalid checksum","details":[]}`, false},
+		{http.StatusNotFound, "404 page not found", false},
+		{http.StatusNotFound, "", false},
+		{http.StatusInternalServerError, `{"code":5,"message":"not found"}`, false},
+	} {
+		err := StatusError{Code: tt.Code, Body: []byte(tt.Body)}
+		require.Equal(t, tt.Want, err.NotFound(), tt)
+	}
 }
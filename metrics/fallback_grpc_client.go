@@ -0,0 +1,334 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slog"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// FallbackGRPCClient is the gRPC counterpart of FallbackClient. It invokes a method on each healthy host in order
+// until one succeeds. Requests and responses are protobuf encoded bytes, so callers decode messages themselves.
+// Like FallbackClient, hosts that fail repeatedly are skipped for a cool-down period, then probed in the background.
+type FallbackGRPCClient struct {
+	hosts    []grpcHost
+	log      *slog.Logger
+	metrics  ClientMetrics
+	chainID  string
+	cooldown time.Duration
+	retry    RetryPolicy
+}
+
+type grpcHost struct {
+	url     url.URL
+	conn    *grpc.ClientConn
+	breaker *circuitBreaker
+	// limiter is nil if the host is not rate limited.
+	limiter *rate.Limiter
+	// md is added to every request, e.g. for authentication. Never log it.
+	md metadata.MD
+	// tls overrides the default TLS config of hosts using the https scheme.
+	tls *tls.Config
+	// dial connects to the host instead of dialing directly, e.g. through a proxy.
+	dial func(ctx context.Context, addr string) (net.Conn, error)
+}
+
+// GRPCOption customizes a FallbackGRPCClient.
+type GRPCOption func(*FallbackGRPCClient)
+
+// WithGRPCChainID labels the client's per-chain metrics with the chain ID. See WithChainID.
+func WithGRPCChainID(chainID string) GRPCOption {
+	return func(c *FallbackGRPCClient) { c.chainID = chainID }
+}
+
+// WithGRPCRetry retries transient failures, such as the Unavailable code, before falling back to the next host.
+func WithGRPCRetry(policy RetryPolicy) GRPCOption {
+	return func(c *FallbackGRPCClient) { c.retry = policy }
+}
+
+// WithGRPCRateLimit limits requests to the host. See WithRateLimit.
+func WithGRPCRateLimit(host url.URL, requestsPerSecond float64, burst int) GRPCOption {
+	return func(c *FallbackGRPCClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				c.hosts[i].limiter = newRateLimiter(requestsPerSecond, burst)
+			}
+		}
+	}
+}
+
+// WithGRPCHeader adds the header to every request to the host as gRPC metadata, e.g. an API key.
+func WithGRPCHeader(host url.URL, header http.Header) GRPCOption {
+	return func(c *FallbackGRPCClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				md := metadata.MD{}
+				for k, v := range header {
+					md.Append(k, v...)
+				}
+				c.hosts[i].md = md
+			}
+		}
+	}
+}
+
+// WithGRPCTLS connects to the host with its own TLS config, e.g. for a private CA or mutual TLS.
+// Only applies to hosts using the https scheme.
+func WithGRPCTLS(host url.URL, cfg *tls.Config) GRPCOption {
+	return func(c *FallbackGRPCClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				c.hosts[i].tls = cfg
+			}
+		}
+	}
+}
+
+// WithGRPCDialer connects to the host with dial instead of directly, e.g. with ProxyDialer.
+// The host name is passed to dial unresolved, so a proxy can resolve it.
+func WithGRPCDialer(host url.URL, dial func(ctx context.Context, addr string) (net.Conn, error)) GRPCOption {
+	return func(c *FallbackGRPCClient) {
+		for i := range c.hosts {
+			if c.hosts[i].url == host {
+				c.hosts[i].dial = dial
+			}
+		}
+	}
+}
+
+// NewFallbackGRPCClient returns a client for the hosts, e.g. https://grpc.example.com:443 or http://localhost:9090.
+// The https scheme uses TLS. Any other scheme is plaintext. Connections are established lazily.
+func NewFallbackGRPCClient(metrics ClientMetrics, hosts []url.URL, opts ...GRPCOption) (*FallbackGRPCClient, error) {
+	if len(hosts) == 0 {
+		panic("no hosts provided")
+	}
+	client := &FallbackGRPCClient{
+		log:      slog.Default(),
+		metrics:  metrics,
+		cooldown: defaultCircuitCooldown,
+	}
+	for _, host := range hosts {
+		client.hosts = append(client.hosts, grpcHost{url: host})
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	// Breakers record their initial state, so they are created once options have set the chain ID.
+	for i := range client.hosts {
+		host := client.hosts[i].url
+		client.hosts[i].breaker = newCircuitBreaker(func(state CircuitState) { metrics.SetCircuitState(client.chainID, host, state) })
+	}
+	for i, host := range client.hosts {
+		creds := insecure.NewCredentials()
+		if host.url.Scheme == "https" {
+			cfg := host.tls
+			if cfg == nil {
+				cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			creds = credentials.NewTLS(cfg)
+		}
+		target := host.url.Host
+		dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+		if host.dial != nil {
+			// Passthrough instead of the default DNS resolver, which may not resolve hosts only the proxy can reach.
+			target = "passthrough:///" + target
+			dialOpts = append(dialOpts, grpc.WithContextDialer(host.dial))
+		}
+		conn, err := grpc.NewClient(target, dialOpts...)
+		if err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("grpc client for %s: %w", host.url.Host, err)
+		}
+		client.hosts[i].conn = conn
+	}
+	return client, nil
+}
+
+// Invoke calls the gRPC method, e.g. /cosmos.slashing.v1beta1.Query/Params, with a protobuf encoded request
+// and returns the protobuf encoded response.
+func (c FallbackGRPCClient) Invoke(ctx context.Context, method string, req []byte) ([]byte, error) {
+	lastErr := ErrNoHealthyHosts
+	for _, host := range c.hosts {
+		if !host.breaker.allow() {
+			continue
+		}
+		resp, err := c.invokeWithRetry(ctx, host, method, req)
+		if err != nil {
+			c.recordHealth(host, method, req, err)
+			lastErr = err
+			continue
+		}
+		host.breaker.success()
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+func (c FallbackGRPCClient) invoke(ctx context.Context, host grpcHost, method string, req []byte) ([]byte, error) {
+	if host.limiter != nil {
+		throttled, err := throttle(ctx, host.limiter)
+		if throttled > 0 {
+			c.metrics.AddThrottledTime(host.url, throttled)
+		}
+		if err != nil {
+			c.log.Debug("gRPC request not sent", "host", host.url.Hostname(), "method", method, "error", err)
+			c.metrics.IncAPIError(host.url, "rate_limited")
+			return nil, err
+		}
+	}
+	if len(host.md) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Join(host.md))
+	}
+
+	var resp []byte
+	start := time.Now()
+	err := host.conn.Invoke(ctx, method, &req, &resp, grpc.ForceCodec(rawCodec{}))
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		c.metrics.ObserveAPIRequest(host.url, method, status.Code(err).String(), time.Since(start))
+	}
+	if err != nil {
+		c.log.Debug("Failed gRPC request", "host", host.url.Hostname(), "method", method, "error", err)
+		c.recordErrMetric(ctx, host.url, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// invokeWithRetry retries transient failures according to the retry policy. Gives up early if the context
+// deadline would pass before the next attempt.
+func (c FallbackGRPCClient) invokeWithRetry(ctx context.Context, host grpcHost, method string, req []byte) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.invoke(ctx, host, method, req)
+		if err == nil || attempt >= c.retry.maxAttempts() || !isRetryableGRPC(err) {
+			return resp, err
+		}
+		delay := c.retry.delay(attempt, 0)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+		c.metrics.IncAPIError(host.url, "retry")
+		c.log.Debug("Retrying gRPC request", "host", host.url.Hostname(), "method", method, "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// recordHealth records a failed request against the host's circuit. If the circuit opens, the host is probed
+// in the background with the same request after the cool-down.
+func (c FallbackGRPCClient) recordHealth(host grpcHost, method string, req []byte, err error) {
+	if !isGRPCHostFailure(err) {
+		return
+	}
+	if host.breaker.failure() {
+		c.log.Warn("Host failed repeatedly, skipping until healthy", "host", host.url.Hostname(), "cooldown", c.cooldown, "error", err)
+		c.scheduleProbe(host, method, req)
+	}
+}
+
+func (c FallbackGRPCClient) scheduleProbe(host grpcHost, method string, req []byte) {
+	time.AfterFunc(c.cooldown, func() {
+		if !host.breaker.probe() {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		defer cancel()
+		if _, err := c.invoke(ctx, host, method, req); err != nil {
+			c.recordHealth(host, method, req, err)
+			return
+		}
+		host.breaker.success()
+		c.log.Info("Host is healthy again", "host", host.url.Hostname())
+	})
+}
+
+// isRetryableGRPC returns true if the request may succeed if sent again to the same host.
+func isRetryableGRPC(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// isGRPCHostFailure returns true if the error indicates the host is unhealthy, as opposed to a bad request.
+func isGRPCHostFailure(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		// The request was never sent.
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// Close closes all connections.
+func (c FallbackGRPCClient) Close() error {
+	var errs []error
+	for _, host := range c.hosts {
+		if host.conn != nil {
+			errs = append(errs, host.conn.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c FallbackGRPCClient) recordErrMetric(ctx context.Context, host url.URL, err error) {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		// Do not record when the process is shutting down.
+		return
+	}
+	reason := unknownErrReason
+	switch code := status.Code(err); code {
+	case codes.DeadlineExceeded:
+		reason = "timeout"
+	case codes.Unknown:
+	default:
+		reason = strings.ToLower(code.String())
+	}
+	c.metrics.IncAPIError(host, reason)
+}
+
+// rawCodec passes protobuf encoded bytes through as is.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("raw codec: unexpected type %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("raw codec: unexpected type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// Name is proto so servers treat requests as regular protobuf messages.
+func (rawCodec) Name() string { return "proto" }
@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// startGRPCServer starts a server that handles every method with handler and returns its url.
+func startGRPCServer(t *testing.T, handler func(ctx context.Context, method string, req []byte) ([]byte, error)) url.URL {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(stream)
+			var req []byte
+			if err := stream.RecvMsg(&req); err != nil {
+				return err
+			}
+			resp, err := handler(stream.Context(), method, req)
+			if err != nil {
+				return err
+			}
+			return stream.SendMsg(&resp)
+		}),
+	)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return url.URL{Scheme: "http", Host: lis.Addr().String()}
+}
+
+func TestFallbackGRPCClient_Invoke(t *testing.T) {
+	t.Parallel()
+
+	const method = "/cosmos.slashing.v1beta1.Query/Params"
+
+	ok := startGRPCServer(t, func(_ context.Context, gotMethod string, req []byte) ([]byte, error) {
+		require.Equal(t, method, gotMethod)
+		return append([]byte("resp:"), req...), nil
+	})
+	unavailable := startGRPCServer(t, func(context.Context, string, []byte) ([]byte, error) {
+		return nil, status.Error(codes.Unavailable, "boom")
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{ok})
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.Invoke(ctx, method, []byte("req"))
+
+		require.NoError(t, err)
+		require.Equal(t, "resp:req", string(resp))
+		require.Zero(t, metrics.IncClientErrCalls)
+	})
+
+	t.Run("fallback on error", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{unavailable, ok})
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.Invoke(ctx, method, nil)
+
+		require.NoError(t, err)
+		require.Equal(t, "resp:", string(resp))
+		require.Equal(t, 1, metrics.IncClientErrCalls)
+		require.Equal(t, unavailable, metrics.GotHost)
+		require.Equal(t, "unavailable", metrics.GotErrMsg)
+		require.Equal(t, []string{"127.0.0.1 " + method + " Unavailable", "127.0.0.1 " + method + " OK"}, metrics.GotRequests)
+	})
+
+	t.Run("all hosts fail", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{unavailable, unavailable})
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = client.Invoke(ctx, method, nil)
+
+		require.Error(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.Equal(t, 2, metrics.IncClientErrCalls)
+	})
+
+	t.Run("sends headers as metadata", func(t *testing.T) {
+		got := make(chan []string, 1)
+		host := startGRPCServer(t, func(ctx context.Context, _ string, _ []byte) ([]byte, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			got <- md.Get("x-api-key")
+			return nil, nil
+		})
+
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{host},
+			WithGRPCHeader(host, http.Header{"X-Api-Key": []string{"secret"}}))
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = client.Invoke(ctx, method, nil)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"secret"}, <-got)
+	})
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		var calls atomic.Int32
+		flaky := startGRPCServer(t, func(context.Context, string, []byte) ([]byte, error) {
+			if calls.Add(1) == 1 {
+				return nil, status.Error(codes.Unavailable, "boom")
+			}
+			return []byte("ok"), nil
+		})
+
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{flaky},
+			WithGRPCRetry(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}))
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := client.Invoke(ctx, method, nil)
+
+		require.NoError(t, err)
+		require.Equal(t, "ok", string(resp))
+		require.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("skips unhealthy hosts", func(t *testing.T) {
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{unavailable, ok}, WithGRPCChainID("cosmoshub-4"))
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+		client.cooldown = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for i := 0; i < circuitFailureThreshold+2; i++ {
+			_, err = client.Invoke(ctx, method, nil)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, circuitFailureThreshold, metrics.IncClientErrCalls)
+		require.Contains(t, metrics.GotStates, CircuitOpen)
+		require.Equal(t, "cosmoshub-4", metrics.GotChainID)
+	})
+
+	t.Run("client errors do not open circuit", func(t *testing.T) {
+		notFound := startGRPCServer(t, func(context.Context, string, []byte) ([]byte, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		})
+
+		var metrics mockClientMetrics
+		client, err := NewFallbackGRPCClient(&metrics, []url.URL{notFound})
+		require.NoError(t, err)
+		defer client.Close()
+		client.log = nopLogger
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for i := 0; i < circuitFailureThreshold+1; i++ {
+			_, err = client.Invoke(ctx, method, nil)
+			require.Equal(t, codes.NotFound, status.Code(err))
+		}
+
+		require.Equal(t, []CircuitState{CircuitClosed}, metrics.GotStates)
+	})
+
+	t.Run("no hosts", func(t *testing.T) {
+		require.Panics(t, func() {
+			_, _ = NewFallbackGRPCClient(nil, nil)
+		})
+	})
+}
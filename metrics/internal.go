@@ -2,15 +2,28 @@ package metrics
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Internal records metrics that represent the health of sl-exporter itself.
 type Internal struct {
-	refAPIErrors *prometheus.CounterVec
-	// TODO(nix): Reference API requests and histogram of latency.
-	failedTasks *prometheus.CounterVec
+	refAPIErrors   *prometheus.CounterVec
+	refAPIRequests *prometheus.CounterVec
+	refAPILatency  *prometheus.HistogramVec
+	failedTasks    *prometheus.CounterVec
+
+	blockCacheHits   *prometheus.CounterVec
+	blockCacheMisses *prometheus.CounterVec
+
+	streamConnected  *prometheus.GaugeVec
+	streamReconnects *prometheus.CounterVec
+
+	circuitState *prometheus.GaugeVec
+	throttled    *prometheus.CounterVec
+	coalesced    *prometheus.CounterVec
+	staleness    *prometheus.GaugeVec
 }
 
 func NewInternal() *Internal {
@@ -18,10 +31,57 @@ func NewInternal() *Internal {
 		refAPIErrors: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: prometheus.BuildFQName(namespace, "", "reference_api_error_total"),
-				Help: "Number of errors encountered while making external calls to an API to gather reference data.",
+				Help: "Number of errors encountered while making external calls to an API to gather reference data. Reason is the status code or one of timeout, proxy, dns, connection, rate_limited, retry or unknown.",
 			},
 			[]string{"host", "reason"},
 		),
+		refAPIRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, "", "reference_api_requests_total"),
+				Help: "Number of external calls to an API to gather reference data. Code is the response status code or the error reason if there was no response.",
+			},
+			[]string{"host", "path_template", "code"},
+		),
+		refAPILatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    prometheus.BuildFQName(namespace, "", "reference_api_request_duration_seconds"),
+				Help:    "Latency of external calls to an API to gather reference data.",
+				Buckets: prometheus.DefBuckets,
+				// Scrapers that support native histograms get high resolution buckets in addition to the classic buckets.
+				NativeHistogramBucketFactor:     1.1,
+				NativeHistogramMaxBucketNumber:  100,
+				NativeHistogramMinResetDuration: time.Hour,
+			},
+			[]string{"host", "path_template"},
+		),
+		circuitState: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, "", "reference_api_circuit_state"),
+				Help: "State of the circuit breaker for an API endpoint. The current state is 1, others are 0. Requests are only sent to closed endpoints. Endpoint is the scheme, host and port, so REST, RPC and gRPC endpoints on the same host are distinct.",
+			},
+			[]string{"chain_id", "endpoint", "state"},
+		),
+		throttled: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, "", "reference_api_throttled_seconds_total"),
+				Help: "Total seconds requests spent waiting for the client-side rate limit of an API host. The rate is the average number of requests waiting at a time.",
+			},
+			[]string{"host"},
+		),
+		coalesced: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, "", "reference_api_coalesced_requests_total"),
+				Help: "Number of API requests that shared the response of an identical in-flight request instead of calling the API.",
+			},
+			[]string{"path_template"},
+		),
+		staleness: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, "", "reference_api_cache_staleness_seconds"),
+				Help: "Age of the cached API response served because all hosts failed. 0 once a fresh response is received.",
+			},
+			[]string{"chain_id", "path_template"},
+		),
 		failedTasks: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: prometheus.BuildFQName(namespace, "", "task_error_total"),
@@ -29,6 +89,34 @@ func NewInternal() *Internal {
 			},
 			[]string{"group"},
 		),
+		blockCacheHits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, "", "block_cache_hits_total"),
+				Help: "Number of block requests served by the shared per-chain block cache.",
+			},
+			[]string{"chain_id"},
+		),
+		blockCacheMisses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, "", "block_cache_misses_total"),
+				Help: "Number of block requests the shared per-chain block cache forwarded to an API.",
+			},
+			[]string{"chain_id"},
+		),
+		streamConnected: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: prometheus.BuildFQName(namespace, "", "block_stream_connected"),
+				Help: "Whether the websocket block stream is subscribed to the host (1) or not (0). While no host is connected, blocks are polled.",
+			},
+			[]string{"chain_id", "host"},
+		),
+		streamReconnects: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: prometheus.BuildFQName(namespace, "", "block_stream_reconnects_total"),
+				Help: "Number of websocket block stream connection attempts after the first, partitioned by the host attempted.",
+			},
+			[]string{"chain_id", "host"},
+		),
 	}
 }
 
@@ -37,14 +125,88 @@ func (c Internal) IncAPIError(host url.URL, reason string) {
 	c.refAPIErrors.WithLabelValues(host.Hostname(), reason).Inc()
 }
 
+// ObserveAPIRequest records a completed external API call and its latency.
+func (c Internal) ObserveAPIRequest(host url.URL, pathTemplate, code string, latency time.Duration) {
+	c.refAPIRequests.WithLabelValues(host.Hostname(), pathTemplate, code).Inc()
+	c.refAPILatency.WithLabelValues(host.Hostname(), pathTemplate).Observe(latency.Seconds())
+}
+
+// SetCircuitState records the circuit breaker state of an API host for a chain.
+func (c Internal) SetCircuitState(chain string, host url.URL, state CircuitState) {
+	endpoint := endpointLabel(host)
+	for _, s := range circuitStates {
+		var v float64
+		if s == state {
+			v = 1
+		}
+		c.circuitState.WithLabelValues(chain, endpoint, s.String()).Set(v)
+	}
+}
+
+// endpointLabel returns the scheme, host and port of the url. Unlike the hostname, it tells apart
+// endpoints on the same host, such as REST and gRPC. Userinfo, path and query are omitted.
+func endpointLabel(u url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+}
+
+// AddThrottledTime records time spent waiting for the client-side rate limit of an API host.
+// A counter rather than a gauge, so no waits are lost between scrapes.
+func (c Internal) AddThrottledTime(host url.URL, d time.Duration) {
+	c.throttled.WithLabelValues(host.Hostname()).Add(d.Seconds())
+}
+
+// IncCoalescedRequest increments the number of API requests that shared an identical in-flight request.
+func (c Internal) IncCoalescedRequest(pathTemplate string) {
+	c.coalesced.WithLabelValues(pathTemplate).Inc()
+}
+
+// SetCacheStaleness records the age of a stale cached API response for a chain.
+func (c Internal) SetCacheStaleness(chain, pathTemplate string, seconds float64) {
+	c.staleness.WithLabelValues(chain, pathTemplate).Set(seconds)
+}
+
 // IncFailedTask increments the number of failed sl-exporter tasks.
 func (c Internal) IncFailedTask(group string) {
 	c.failedTasks.WithLabelValues(group).Inc()
 }
 
+// IncBlockCacheHit increments the number of blocks served from cache for a chain.
+func (c Internal) IncBlockCacheHit(chain string) {
+	c.blockCacheHits.WithLabelValues(chain).Inc()
+}
+
+// IncBlockCacheMiss increments the number of blocks fetched from an API for a chain.
+func (c Internal) IncBlockCacheMiss(chain string) {
+	c.blockCacheMisses.WithLabelValues(chain).Inc()
+}
+
+// SetStreamConnected records whether the block stream for a chain is connected to the host.
+func (c Internal) SetStreamConnected(chain string, host url.URL, connected bool) {
+	var v float64
+	if connected {
+		v = 1
+	}
+	c.streamConnected.WithLabelValues(chain, host.Hostname()).Set(v)
+}
+
+// IncStreamReconnect increments the number of block stream reconnect attempts for a chain.
+func (c Internal) IncStreamReconnect(chain string, host url.URL) {
+	c.streamReconnects.WithLabelValues(chain, host.Hostname()).Inc()
+}
+
 func (c Internal) Metrics() []prometheus.Collector {
 	return []prometheus.Collector{
 		c.refAPIErrors,
 		c.failedTasks,
+		c.blockCacheHits,
+		c.blockCacheMisses,
+		c.streamConnected,
+		c.streamReconnects,
+		c.refAPIRequests,
+		c.refAPILatency,
+		c.circuitState,
+		c.throttled,
+		c.coalesced,
+		c.staleness,
 	}
 }
@@ -7,4 +7,5 @@ const (
 	staticSubsystem    = "static"
 	cosmosSubsystem    = "cosmos"
 	cosmosValSubsystem = cosmosSubsystem + "_val"
+	cosmosGovSubsystem = cosmosSubsystem + "_gov"
 )
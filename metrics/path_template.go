@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// templatePath replaces variable path segments, such as addresses and heights, with placeholders so request
+// metrics have bounded cardinality. E.g. /cosmos/bank/v1beta1/balances/cosmos1.../by_denom becomes
+// /cosmos/bank/v1beta1/balances/{address}/by_denom. The query string is not part of the path.
+func templatePath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		switch {
+		case seg == "":
+		case isNumber(seg):
+			segments[i] = "{number}"
+		case isHash(seg):
+			segments[i] = "{hash}"
+		case isBech32(seg):
+			segments[i] = "{address}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// isHash returns true for SHA256 hashes in hex such as IBC denom hashes and tx hashes.
+func isHash(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func isBech32(s string) bool {
+	_, _, err := bech32.DecodeAndConvert(s)
+	return err == nil
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatePath(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Path, Want string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/block", "/block"},
+		{"/cosmos/base/tendermint/v1beta1/blocks/latest", "/cosmos/base/tendermint/v1beta1/blocks/latest"},
+		{"/cosmos/base/tendermint/v1beta1/blocks/15312655", "/cosmos/base/tendermint/v1beta1/blocks/{number}"},
+		{"/cosmos/bank/v1beta1/balances/cosmos1xyerxdp4xcmnswfsxyerxdp4xcmnswfs2mnmda/by_denom", "/cosmos/bank/v1beta1/balances/{address}/by_denom"},
+		{"/cosmos/slashing/v1beta1/signing_infos/cosmosvalcons1l4gv2j2h0p6cf7e9tw7emxcrgxsvf7l8m0l56g", "/cosmos/slashing/v1beta1/signing_infos/{address}"},
+		{"/cosmos/gov/v1/proposals/42/votes/cosmos1xyerxdp4xcmnswfsxyerxdp4xcmnswfs2mnmda", "/cosmos/gov/v1/proposals/{number}/votes/{address}"},
+		{"/ibc/apps/transfer/v1/denom_traces/B05539B66B72E2739B986B86391E5D08F12B8D5D2C2A7F8F8CF9ADF674DFA231", "/ibc/apps/transfer/v1/denom_traces/{hash}"},
+		{"/cosmos/staking/v1beta1/validators/cosmosvaloper1clpqr4nrk4khgkxj78fcwwh6dl3uw4epsluffn", "/cosmos/staking/v1beta1/validators/{address}"},
+		{"/ibc/apps/transfer/v1/denoms/B05539B66B72E2739B986B86391E5D08F12B8D5D2C2A7F8F8CF9ADF674DFA231", "/ibc/apps/transfer/v1/denoms/{hash}"},
+		// Invalid checksum is not an address.
+		{"/cosmos/staking/v1beta1/validators/cosmosvaloper1invalid", "/cosmos/staking/v1beta1/validators/cosmosvaloper1invalid"},
+	} {
+		require.Equal(t, tt.Want, templatePath(tt.Path), tt.Path)
+	}
+}
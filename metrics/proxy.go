@@ -0,0 +1,179 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	netproxy "golang.org/x/net/proxy"
+)
+
+// ProxyError is returned when a proxy refuses to connect to the upstream host.
+type ProxyError struct {
+	Status string
+}
+
+func (e ProxyError) Error() string { return "proxy connect: " + e.Status }
+
+// ConfigureProxy routes the transport's requests through the proxy, e.g. http://proxy:3128 or socks5://proxy:1080.
+// Failures to connect to or through the proxy are recorded with the proxy reason instead of as upstream failures.
+func ConfigureProxy(transport *http.Transport, proxy *url.URL) {
+	transport.Proxy = http.ProxyURL(proxy)
+	// Requests to http upstreams are sent to the proxy without a CONNECT, so a failed dial to the proxy
+	// must be told apart from a failed dial to the upstream host by its address.
+	dial := transport.DialContext
+	if dial == nil {
+		dial = new(net.Dialer).DialContext
+	}
+	proxyAddr := proxyAddress(proxy)
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil && addr == proxyAddr {
+			return nil, &net.OpError{Op: "proxyconnect", Net: network, Err: err}
+		}
+		return conn, err
+	}
+	transport.OnProxyConnectResponse = func(ctx context.Context, proxyURL *url.URL, req *http.Request, resp *http.Response) error {
+		if resp.StatusCode != http.StatusOK {
+			return ProxyError{Status: resp.Status}
+		}
+		return nil
+	}
+}
+
+// ProxyDialer returns a dialer that connects to addr, a host:port, through the proxy. For clients that cannot be
+// configured with ConfigureProxy, e.g. gRPC. HTTP and HTTPS proxies are sent a CONNECT request.
+// Like ConfigureProxy, failures to connect to or through the proxy are proxy errors.
+func ProxyDialer(proxy *url.URL) func(ctx context.Context, addr string) (net.Conn, error) {
+	if proxy.Scheme == "socks5" || proxy.Scheme == "socks5h" {
+		return func(ctx context.Context, addr string) (net.Conn, error) {
+			dialer, err := netproxy.FromURL(proxy, netproxy.Direct)
+			if err != nil {
+				return nil, err
+			}
+			ctxDialer, ok := dialer.(netproxy.ContextDialer)
+			if !ok {
+				return nil, fmt.Errorf("proxy scheme %s does not support contexts", proxy.Scheme)
+			}
+			return ctxDialer.DialContext(ctx, "tcp", addr)
+		}
+	}
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		return dialConnect(ctx, proxy, addr)
+	}
+}
+
+// dialConnect opens a tunnel to addr through an HTTP or HTTPS proxy.
+func dialConnect(ctx context.Context, proxy *url.URL, addr string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddress(proxy))
+	if err != nil {
+		return nil, &net.OpError{Op: "proxyconnect", Net: "tcp", Err: err}
+	}
+	// Abort the handshake when ctx is done.
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if proxy.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxy.Hostname(), MinVersion: tls.VersionTLS12})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, &net.OpError{Op: "proxyconnect", Net: "tcp", Err: err}
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := proxy.User; user != nil {
+		password, _ := user.Password()
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user.Username()+":"+password)))
+	}
+	br := bufio.NewReader(conn)
+	resp, err := func() (*http.Response, error) {
+		if err := req.Write(conn); err != nil {
+			return nil, err
+		}
+		return http.ReadResponse(br, req)
+	}()
+	if err != nil {
+		_ = conn.Close()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return nil, &net.OpError{Op: "proxyconnect", Net: "tcp", Err: err}
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, ProxyError{Status: resp.Status}
+	}
+	if !stop() {
+		_ = conn.Close()
+		return nil, &net.OpError{Op: "proxyconnect", Net: "tcp", Err: fmt.Errorf("connect to %s: %w", addr, ctx.Err())}
+	}
+	if br.Buffered() > 0 {
+		// The upstream host may send data right away, e.g. the HTTP/2 server preface.
+		return bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn reads data buffered while reading the CONNECT response before reading from the connection.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c bufferedConn) Read(b []byte) (int, error) { return c.r.Read(b) }
+
+// proxyAddress returns the host:port the transport dials for the proxy.
+func proxyAddress(proxy *url.URL) string {
+	if port := proxy.Port(); port != "" {
+		return net.JoinHostPort(proxy.Hostname(), port)
+	}
+	port := "80"
+	switch proxy.Scheme {
+	case "https":
+		port = "443"
+	case "socks5", "socks5h":
+		port = "1080"
+	}
+	return net.JoinHostPort(proxy.Hostname(), port)
+}
+
+// isProxyError returns true if the request failed connecting to or through a proxy.
+func isProxyError(err error) bool {
+	if errors.As(err, new(ProxyError)) {
+		return true
+	}
+	// The transport returns these ops for failed connections to HTTP and SOCKS proxies.
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks"))
+}
+
+// connErrReason returns the reason for a failed connection to the upstream host.
+// Returns false if err is not a connection error.
+func connErrReason(err error) (string, bool) {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return "dns", true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return "connection", true
+	}
+	return "", false
+}
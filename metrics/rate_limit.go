@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned when a request is not sent because of the client-side rate limit of a host.
+var ErrRateLimited = errors.New("rate limited")
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rate.Limiter {
+	if burst <= 0 {
+		burst = max(1, int(math.Ceil(requestsPerSecond)))
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
+// throttle waits until the limiter allows a request. Returns the time spent waiting.
+// Fails immediately if the context deadline would pass before the limiter allows a request.
+func throttle(ctx context.Context, limiter *rate.Limiter) (time.Duration, error) {
+	r := limiter.Reserve()
+	delay := r.Delay()
+	if delay == 0 {
+		return 0, nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		r.Cancel()
+		return 0, fmt.Errorf("%w: next request allowed in %s", ErrRateLimited, delay)
+	}
+	start := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		r.Cancel()
+		return time.Since(start), fmt.Errorf("%w: %w", ErrRateLimited, ctx.Err())
+	case <-timer.C:
+		return delay, nil
+	}
+}
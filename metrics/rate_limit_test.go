@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 3, newRateLimiter(2.5, 0).Burst())
+	require.Equal(t, 1, newRateLimiter(0.1, 0).Burst())
+	require.Equal(t, 10, newRateLimiter(1, 10).Burst())
+}
+
+func TestThrottle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("waits for token", func(t *testing.T) {
+		limiter := newRateLimiter(100, 1)
+
+		waited, err := throttle(context.Background(), limiter)
+		require.NoError(t, err)
+		require.Zero(t, waited)
+
+		waited, err = throttle(context.Background(), limiter)
+		require.NoError(t, err)
+		require.Greater(t, waited, time.Duration(0))
+		require.LessOrEqual(t, waited, 10*time.Millisecond)
+	})
+
+	t.Run("deadline too soon", func(t *testing.T) {
+		limiter := newRateLimiter(0.1, 1)
+		require.True(t, limiter.Allow())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		waited, err := throttle(ctx, limiter)
+		require.ErrorIs(t, err, ErrRateLimited)
+		require.Zero(t, waited)
+
+		// The canceled reservation does not consume a token.
+		require.InDelta(t, 0, limiter.Tokens(), 0.2)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		limiter := newRateLimiter(0.1, 1)
+		require.True(t, limiter.Allow())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		waited, err := throttle(ctx, limiter)
+		require.ErrorIs(t, err, ErrRateLimited)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Greater(t, waited, time.Duration(0))
+	})
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheTTLs maps path templates, e.g. /cosmos/slashing/v1beta1/params, to how long responses stay fresh.
+// Only paths with a TTL are cached. See templatePath for the template format.
+type CacheTTLs map[string]time.Duration
+
+// responseCache caches responses of slow-changing paths. Entries are kept after they expire, so they can be
+// revalidated with an ETag or served stale if all hosts fail. A nil cache caches nothing.
+type responseCache struct {
+	ttls CacheTTLs
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	response sharedResponse
+	// validator revalidates the entry with the host that served it. Nil if the host sent no ETag.
+	validator *cacheValidator
+	fetchedAt time.Time
+	ttl       time.Duration
+}
+
+// cacheValidator sends If-None-Match to the host. ETags are not comparable across hosts.
+type cacheValidator struct {
+	host url.URL
+	etag string
+}
+
+func newResponseCache(ttls CacheTTLs) *responseCache {
+	return &responseCache{
+		ttls:    ttls,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// lookup returns the entry for the path regardless of its age.
+func (c *responseCache) lookup(path url.URL) (cacheEntry, bool) {
+	if c == nil {
+		return cacheEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[path.String()]
+	return entry, ok
+}
+
+// fresh returns the cached response if it has not expired.
+func (c *responseCache) fresh(path url.URL) (sharedResponse, bool) {
+	entry, ok := c.lookup(path)
+	if !ok || c.age(entry) >= entry.ttl {
+		return sharedResponse{}, false
+	}
+	return entry.response, true
+}
+
+func (c *responseCache) age(entry cacheEntry) time.Duration {
+	return c.now().Sub(entry.fetchedAt)
+}
+
+// store caches the response from the host if the path has a TTL. Cache-Control from the host takes precedence
+// over the TTL. Returns true if the response was cached.
+func (c *responseCache) store(path url.URL, host url.URL, resp sharedResponse) bool {
+	if c == nil {
+		return false
+	}
+	ttl, ok := c.ttls[templatePath(path.Path)]
+	if !ok {
+		return false
+	}
+	key := path.String()
+	ttl, cacheable := cacheControlTTL(resp.resp.Header, ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !cacheable {
+		delete(c.entries, key)
+		return false
+	}
+	entry := cacheEntry{
+		response:  resp,
+		fetchedAt: c.now(),
+		ttl:       ttl,
+	}
+	if etag := resp.resp.Header.Get("ETag"); etag != "" {
+		entry.validator = &cacheValidator{host: host, etag: etag}
+	}
+	c.entries[key] = entry
+	return true
+}
+
+// refresh marks the entry as fresh after the host responded 304 Not Modified.
+func (c *responseCache) refresh(path url.URL, entry cacheEntry, header http.Header) {
+	ttl, ok := c.ttls[templatePath(path.Path)]
+	if !ok {
+		return
+	}
+	entry.ttl, _ = cacheControlTTL(header, ttl)
+	entry.fetchedAt = c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path.String()] = entry
+}
+
+// cacheControlTTL returns the TTL given the Cache-Control header, or ttl if the header has no max-age.
+// Returns false if the response must not be stored. With no-cache, the response is stored but always revalidated.
+func cacheControlTTL(header http.Header, ttl time.Duration) (time.Duration, bool) {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			return 0, false
+		case "no-cache":
+			return 0, true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				ttl = max(time.Duration(seconds)*time.Second, 0)
+			}
+		}
+	}
+	return ttl, true
+}
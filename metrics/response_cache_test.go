@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheControlTTL(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		CacheControl string
+		WantTTL      time.Duration
+		WantOK       bool
+	}{
+		{"", time.Minute, true},
+		{"public", time.Minute, true},
+		{"max-age=30", 30 * time.Second, true},
+		{"public, max-age=3600", time.Hour, true},
+		{"max-age=invalid", time.Minute, true},
+		{"no-cache", 0, true},
+		{"no-store", 0, false},
+		{"No-Store, max-age=60", 0, false},
+	} {
+		header := http.Header{"Cache-Control": {tt.CacheControl}}
+		ttl, ok := cacheControlTTL(header, time.Minute)
+
+		require.Equal(t, tt.WantTTL, ttl, tt.CacheControl)
+		require.Equal(t, tt.WantOK, ok, tt.CacheControl)
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	host := url.URL{Scheme: "http", Host: "1.example.com"}
+	stubResponse := func(header http.Header) sharedResponse {
+		return sharedResponse{resp: &http.Response{StatusCode: http.StatusOK, Header: header}, body: []byte("cached")}
+	}
+
+	t.Run("fresh until ttl expires", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		cache := newResponseCache(CacheTTLs{"/v1/params": time.Minute})
+		cache.now = func() time.Time { return now }
+
+		path := url.URL{Path: "/v1/params"}
+		_, ok := cache.fresh(path)
+		require.False(t, ok)
+
+		require.True(t, cache.store(path, host, stubResponse(http.Header{})))
+		got, ok := cache.fresh(path)
+		require.True(t, ok)
+		require.Equal(t, []byte("cached"), got.body)
+
+		now = now.Add(time.Minute)
+		_, ok = cache.fresh(path)
+		require.False(t, ok)
+
+		// Expired entries are kept for revalidation and stale responses.
+		entry, ok := cache.lookup(path)
+		require.True(t, ok)
+		require.Equal(t, time.Minute, cache.age(entry))
+	})
+
+	t.Run("path templates", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newResponseCache(CacheTTLs{"/v1/validators/{address}": time.Minute})
+
+		path := url.URL{Path: "/v1/validators/cosmosvaloper1ey69r37gfxvxg62sh4r0ktpuc46pzjrm873ae8"}
+		require.True(t, cache.store(path, host, stubResponse(http.Header{})))
+		_, ok := cache.fresh(path)
+		require.True(t, ok)
+
+		// Other addresses are cached separately.
+		_, ok = cache.fresh(url.URL{Path: "/v1/validators/cosmosvaloper1clpqr4nrk4khgkxj78fcwwh6dl3uw4epsluffn"})
+		require.False(t, ok)
+
+		require.False(t, cache.store(url.URL{Path: "/v1/other"}, host, stubResponse(http.Header{})))
+	})
+
+	t.Run("honors cache-control", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newResponseCache(CacheTTLs{"/v1/params": time.Minute})
+		path := url.URL{Path: "/v1/params"}
+
+		require.True(t, cache.store(path, host, stubResponse(http.Header{"Cache-Control": {"max-age=600"}})))
+		entry, ok := cache.lookup(path)
+		require.True(t, ok)
+		require.Equal(t, 10*time.Minute, entry.ttl)
+
+		// no-store evicts the previous entry.
+		require.False(t, cache.store(path, host, stubResponse(http.Header{"Cache-Control": {"no-store"}})))
+		_, ok = cache.lookup(path)
+		require.False(t, ok)
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		cache := newResponseCache(CacheTTLs{"/v1/params": time.Minute})
+		cache.now = func() time.Time { return now }
+		path := url.URL{Path: "/v1/params"}
+
+		require.True(t, cache.store(path, host, stubResponse(http.Header{"Etag": {`"v1"`}})))
+		entry, ok := cache.lookup(path)
+		require.True(t, ok)
+		require.Equal(t, &cacheValidator{host: host, etag: `"v1"`}, entry.validator)
+
+		now = now.Add(2 * time.Minute)
+		cache.refresh(path, entry, http.Header{})
+		got, ok := cache.fresh(path)
+		require.True(t, ok)
+		require.Equal(t, []byte("cached"), got.body)
+	})
+
+	t.Run("nil cache", func(t *testing.T) {
+		t.Parallel()
+
+		var cache *responseCache
+		path := url.URL{Path: "/v1/params"}
+		require.False(t, cache.store(path, host, stubResponse(http.Header{})))
+		_, ok := cache.fresh(path)
+		require.False(t, ok)
+	})
+}
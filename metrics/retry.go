@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryBackoff    = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy configures how often a FallbackClient retries a host before falling back to the next host.
+// The zero value does not retry.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts per host, including the first request. Defaults to 1, i.e. no retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with each subsequent retry and is randomized
+	// by up to 50% to avoid synchronized retries. Defaults to 500ms.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries. Defaults to 5s.
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	return max(p.MaxAttempts, 1)
+}
+
+// delay returns how long to wait before the retry following the attempt, starting at 1.
+// A Retry-After from the server is honored if longer than the backoff.
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	backoff := p.Backoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, maxBackoff)
+	// Equal jitter: wait at least half the backoff.
+	backoff = backoff/2 + rand.N(backoff/2+1)
+	return max(backoff, retryAfter)
+}
+
+// retryableStatusCodes are transient failures where the same request may succeed later.
+var retryableStatusCodes = map[int]bool{
+	http.StatusRequestTimeout:     true,
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// isRetryable returns true if the request may succeed if sent again to the same host.
+func isRetryable(err error) bool {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return retryableStatusCodes[statusErr.Code]
+	}
+	// Deadline exceeded or canceled means the caller gave up. Rate limited requests fall back to the next host.
+	return !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) && !errors.Is(err, ErrRateLimited)
+}
+
+// parseRetryAfter parses the Retry-After header, which is either seconds or an HTTP date.
+// Returns 0 if the header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for _, tt := range []struct {
+		Attempt  int
+		Min, Max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+		{1000, 500 * time.Millisecond, time.Second},
+	} {
+		for i := 0; i < 100; i++ {
+			got := policy.delay(tt.Attempt, 0)
+			require.GreaterOrEqual(t, got, tt.Min, tt)
+			require.LessOrEqual(t, got, tt.Max, tt)
+		}
+	}
+
+	require.Equal(t, 3*time.Second, policy.delay(1, 3*time.Second))
+
+	var zero RetryPolicy
+	require.Equal(t, 1, zero.maxAttempts())
+	require.LessOrEqual(t, zero.delay(1, 0), defaultRetryBackoff)
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		Err  error
+		Want bool
+	}{
+		{errors.New("connection reset"), true},
+		{StatusError{Code: http.StatusTooManyRequests}, true},
+		{StatusError{Code: http.StatusServiceUnavailable}, true},
+		{fmt.Errorf("wrapped: %w", StatusError{Code: http.StatusBadGateway}), true},
+		{StatusError{Code: http.StatusInternalServerError}, false},
+		{StatusError{Code: http.StatusNotFound}, false},
+		{context.DeadlineExceeded, false},
+		{context.Canceled, false},
+	} {
+		require.Equal(t, tt.Want, isRetryable(tt.Err), tt.Err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Zero(t, parseRetryAfter("", now))
+	require.Zero(t, parseRetryAfter("soon", now))
+	require.Zero(t, parseRetryAfter("-5", now))
+	require.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	require.Equal(t, 10*time.Second, parseRetryAfter("Sun, 01 Jan 2023 00:00:10 GMT", now))
+	require.Zero(t, parseRetryAfter("Sat, 31 Dec 2022 00:00:00 GMT", now))
+}
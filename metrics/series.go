@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// seriesSet is a set of label sets of a metric vector.
+type seriesSet map[string]struct{}
+
+func (s seriesSet) add(labels prometheus.Labels) { s[seriesKey(labels)] = struct{}{} }
+
+func (s seriesSet) has(labels prometheus.Labels) bool {
+	_, ok := s[seriesKey(labels)]
+	return ok
+}
+
+func seriesKey(labels prometheus.Labels) string {
+	names := slices.Sorted(maps.Keys(labels))
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(labels[name])
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+// deleteVanished deletes the series of vec that match all of match and are not in keep.
+// Unlike DeletePartialMatch before setting the current series, series that are kept never disappear from a scrape.
+func deleteVanished(vec *prometheus.MetricVec, match prometheus.Labels, keep seriesSet) {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		vec.Collect(ch)
+		close(ch)
+	}()
+
+	// The vector is locked while collecting, so delete after draining the channel.
+	var vanished []prometheus.Labels
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			continue
+		}
+		labels := make(prometheus.Labels, len(pb.GetLabel()))
+		for _, pair := range pb.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		if matchesLabels(labels, match) && !keep.has(labels) {
+			vanished = append(vanished, labels)
+		}
+	}
+	for _, labels := range vanished {
+		vec.Delete(labels)
+	}
+}
+
+func matchesLabels(labels, match prometheus.Labels) bool {
+	for name, value := range match {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
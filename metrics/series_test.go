@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteVanished(t *testing.T) {
+	t.Parallel()
+
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge"}, []string{"chain_id", "denom"})
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(vec)
+
+	vec.WithLabelValues("cosmoshub-4", "uatom").Set(1)
+	vec.WithLabelValues("cosmoshub-4", "ibc/ABC").Set(2)
+	vec.WithLabelValues("osmosis-1", "uosmo").Set(3)
+
+	keep := make(seriesSet)
+	keep.add(prometheus.Labels{"chain_id": "cosmoshub-4", "denom": "uatom"})
+	// Does not match, so it is not deleted even though it is not kept.
+	deleteVanished(vec.MetricVec, prometheus.Labels{"chain_id": "cosmoshub-4"}, keep)
+
+	r := httptest.NewRecorder()
+	metricsHandler(reg).ServeHTTP(r, stubRequest)
+
+	require.Contains(t, r.Body.String(), `test_gauge{chain_id="cosmoshub-4",denom="uatom"} 1`)
+	require.Contains(t, r.Body.String(), `test_gauge{chain_id="osmosis-1",denom="uosmo"} 3`)
+	require.NotContains(t, r.Body.String(), `ibc/ABC`)
+
+	// Nothing kept deletes every match.
+	deleteVanished(vec.MetricVec, prometheus.Labels{"chain_id": "cosmoshub-4"}, nil)
+
+	r = httptest.NewRecorder()
+	metricsHandler(reg).ServeHTTP(r, stubRequest)
+
+	require.NotContains(t, r.Body.String(), "cosmoshub-4")
+	require.Contains(t, r.Body.String(), "osmosis-1")
+}